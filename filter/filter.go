@@ -0,0 +1,198 @@
+package filter
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/response"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Operator compares a field with the values of a condition
+type Operator string
+
+const (
+	Eq   Operator = "eq"
+	Ne   Operator = "ne"
+	Gt   Operator = "gt"
+	Gte  Operator = "gte"
+	Lt   Operator = "lt"
+	Lte  Operator = "lte"
+	In   Operator = "in"
+	Nin  Operator = "nin"
+	Like Operator = "like"
+)
+
+// FieldType is the type the values of a field are parsed to
+type FieldType int
+
+const (
+	String FieldType = iota
+	Int
+	Float
+	Bool
+	Date
+)
+
+var operators = []Operator{Eq, Ne, Gt, Gte, Lt, Lte, In, Nin, Like}
+
+var operatorsByType = map[FieldType][]Operator{
+	String: {Eq, Ne, In, Nin, Like, Gt, Gte, Lt, Lte},
+	Int:    {Eq, Ne, In, Nin, Gt, Gte, Lt, Lte},
+	Float:  {Eq, Ne, In, Nin, Gt, Gte, Lt, Lte},
+	Bool:   {Eq, Ne},
+	Date:   {Eq, Ne, In, Nin, Gt, Gte, Lt, Lte},
+}
+
+// Whitelist has the fields that can be filtered and their types
+type Whitelist map[string]FieldType
+
+// Condition compares a field with typed values: one value,
+// except for In and Nin
+type Condition struct {
+	Field    string
+	Operator Operator
+	Values   []any
+}
+
+// Filter is the conjunction of its conditions
+type Filter struct {
+	Conditions []Condition
+}
+
+type parseOption struct {
+	ignored []string
+}
+
+type ParseOptions func(*parseOption)
+
+// WithIgnored adds query parameters that are not filters.
+// page, size, sort, cursor and fields are always ignored
+func WithIgnored(params ...string) ParseOptions {
+	return func(o *parseOption) {
+		o.ignored = append(o.ignored, params...)
+	}
+}
+
+// Filter errors
+var (
+	UnknownFieldError = response.Register(response.Definition{
+		Key:        "INVALID_FILTER.field",
+		Code:       "INVALID_FILTER",
+		HttpStatus: fiber.StatusBadRequest,
+		Message:    "Filter field {0} is not allowed",
+		Translations: map[string]response.Message{
+			"es": "El campo de filtro {0} no está permitido",
+			"pt": "O campo de filtro {0} não é permitido",
+		},
+	})
+	OperatorError = response.Register(response.Definition{
+		Key:        "INVALID_FILTER.operator",
+		Code:       "INVALID_FILTER",
+		HttpStatus: fiber.StatusBadRequest,
+		Message:    "Operator {0} is not allowed for field {1}",
+		Translations: map[string]response.Message{
+			"es": "El operador {0} no está permitido para el campo {1}",
+			"pt": "O operador {0} não é permitido para o campo {1}",
+		},
+	})
+	ValueError = response.Register(response.Definition{
+		Key:        "INVALID_FILTER.value",
+		Code:       "INVALID_FILTER",
+		HttpStatus: fiber.StatusBadRequest,
+		Message:    "Invalid value {0} for field {1}",
+		Translations: map[string]response.Message{
+			"es": "Valor {0} inválido para el campo {1}",
+			"pt": "Valor {0} inválido para o campo {1}",
+		},
+	})
+)
+
+// Parse parses the query parameters as conditions (e.g.
+// ?status=in:OPEN,CLOSED&createDate=gte:2024-01-01&name=like:foo). Without
+// operator prefix the operator is Eq. A field can be repeated for ranges
+func Parse(c *fiber.Ctx, span trace.Span, whitelist Whitelist, options ...ParseOptions) (Filter, *response.ResponseError) {
+	opts := parseOption{ignored: []string{"page", "size", "sort", "cursor", "fields"}}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	re := &response.ResponseError{}
+	var filter Filter
+
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		field := string(key)
+		if slices.Contains(opts.ignored, field) {
+			return
+		}
+
+		fieldType, ok := whitelist[field]
+		if !ok {
+			re.AddDefinition(span, UnknownFieldError, field)
+			return
+		}
+
+		condition, def, args := parseCondition(field, fieldType, string(value))
+		if def != nil {
+			re.AddDefinition(span, *def, args...)
+			return
+		}
+		filter.Conditions = append(filter.Conditions, condition)
+	})
+
+	if len(re.Errors) > 0 {
+		return Filter{}, re
+	}
+	return filter, nil
+}
+
+func parseCondition(field string, fieldType FieldType, expression string) (Condition, *response.Definition, []any) {
+	operator, value := Eq, expression
+	if op, rest, ok := strings.Cut(expression, ":"); ok && slices.Contains(operators, Operator(op)) {
+		operator, value = Operator(op), rest
+	}
+
+	if !slices.Contains(operatorsByType[fieldType], operator) {
+		return Condition{}, &OperatorError, []any{operator, field}
+	}
+
+	raw := []string{value}
+	if operator == In || operator == Nin {
+		raw = strings.Split(value, ",")
+	}
+
+	values := make([]any, 0, len(raw))
+	for _, r := range raw {
+		v, ok := parseValue(fieldType, r)
+		if !ok {
+			return Condition{}, &ValueError, []any{r, field}
+		}
+		values = append(values, v)
+	}
+	return Condition{Field: field, Operator: operator, Values: values}, nil, nil
+}
+
+func parseValue(fieldType FieldType, value string) (any, bool) {
+	switch fieldType {
+	case Int:
+		v, err := strconv.ParseInt(value, 10, 64)
+		return v, err == nil
+	case Float:
+		v, err := strconv.ParseFloat(value, 64)
+		return v, err == nil
+	case Bool:
+		v, err := strconv.ParseBool(value)
+		return v, err == nil
+	case Date:
+		if v, err := time.Parse(time.DateOnly, value); err == nil {
+			return v, true
+		}
+		v, err := time.Parse(time.RFC3339, value)
+		return v.UTC(), err == nil
+	default:
+		return value, value != ""
+	}
+}
@@ -0,0 +1,122 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/auditory"
+	"github.com/javiorfo/go-microservice-lib/internal/fibertest"
+	"github.com/javiorfo/go-microservice-lib/response"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var whitelist = Whitelist{
+	"status":     String,
+	"createDate": Date,
+	"name":       String,
+	"stock":      Int,
+	"active":     Bool,
+}
+
+type columns map[string]string
+
+func (c columns) MapFieldToSQLColumn(field string) string {
+	if column, ok := c[field]; ok {
+		return column
+	}
+	return field
+}
+
+func parse(t *testing.T, query string) (Filter, *response.ResponseError) {
+	return fibertest.ParseQuery(t, query, func(c *fiber.Ctx) (Filter, *response.ResponseError) {
+		return Parse(c, noop.Span{}, whitelist)
+	})
+}
+
+func TestParse(t *testing.T) {
+	filter, re := parse(t, "status=in:OPEN,CLOSED&createDate=gte:2024-01-01&name=like:fo_o&stock=lt:5&active=true&page=2")
+	if re != nil {
+		t.Fatalf("Unexpected errors %v", re.Errors)
+	}
+
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := []Condition{
+		{"status", In, []any{"OPEN", "CLOSED"}},
+		{"createDate", Gte, []any{date}},
+		{"name", Like, []any{"fo_o"}},
+		{"stock", Lt, []any{int64(5)}},
+		{"active", Eq, []any{true}},
+	}
+	if !reflect.DeepEqual(filter.Conditions, expected) {
+		t.Fatalf("Conditions must be %v. Got %v", expected, filter.Conditions)
+	}
+
+	where, args := filter.SQL(auditory.Auditable{})
+	if where != `status IN (?, ?) AND create_date >= ? AND LOWER(name) LIKE ? ESCAPE '!' AND stock < ? AND active = ?` {
+		t.Errorf("Unexpected SQL %s", where)
+	}
+	if !reflect.DeepEqual(args, []any{"OPEN", "CLOSED", date, `%fo!_o%`, int64(5), true}) {
+		t.Errorf("Unexpected SQL args %v", args)
+	}
+
+	expectedBson := bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "status", Value: bson.D{{Key: "$in", Value: bson.A{"OPEN", "CLOSED"}}}}},
+		bson.D{{Key: "createDate", Value: bson.D{{Key: "$gte", Value: date}}}},
+		bson.D{{Key: "name", Value: bson.D{{Key: "$regex", Value: "fo_o"}, {Key: "$options", Value: "i"}}}},
+		bson.D{{Key: "stock", Value: bson.D{{Key: "$lt", Value: int64(5)}}}},
+		bson.D{{Key: "active", Value: true}},
+	}}}
+	if !reflect.DeepEqual(filter.Bson(), expectedBson) {
+		t.Errorf("Bson must be %v. Got %v", expectedBson, filter.Bson())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	_, re := parse(t, "password=x&active=gt:true&stock=abc")
+	if re == nil {
+		t.Fatal("Invalid filter must fail")
+	}
+
+	expected := []string{
+		"Filter field password is not allowed",
+		"Operator gt is not allowed for field active",
+		"Invalid value abc for field stock",
+	}
+	if len(re.Errors) != len(expected) {
+		t.Fatalf("Errors must be %v. Got %v", expected, re.Errors)
+	}
+	for i, e := range re.Errors {
+		if e.Code != "INVALID_FILTER" || e.Message != expected[i] {
+			t.Errorf("Unexpected error %+v", e)
+		}
+	}
+}
+
+func TestEmptyFilter(t *testing.T) {
+	filter, re := parse(t, "")
+	if re != nil {
+		t.Fatal(re)
+	}
+
+	if len(filter.Bson()) != 0 {
+		t.Errorf("Bson must be empty. Got %v", filter.Bson())
+	}
+	if where, args := filter.SQL(nil); where != "" || args != nil {
+		t.Errorf("SQL must be empty. Got %s %v", where, args)
+	}
+}
+
+func TestLikeSQL(t *testing.T) {
+	filter := Filter{Conditions: []Condition{{"name", Like, []any{"50%_Off!"}}}}
+
+	where, args := filter.SQL(columns{"name": "product_name"})
+	if where != `LOWER(product_name) LIKE ? ESCAPE '!'` {
+		t.Errorf("Unexpected SQL %s", where)
+	}
+	if !reflect.DeepEqual(args, []any{`%50!%!_off!!%`}) {
+		t.Errorf("Unexpected SQL args %v", args)
+	}
+}
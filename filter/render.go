@@ -0,0 +1,96 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/javiorfo/go-microservice-lib/response"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var mongoOperators = map[Operator]string{
+	Ne:  "$ne",
+	Gt:  "$gt",
+	Gte: "$gte",
+	Lt:  "$lt",
+	Lte: "$lte",
+	In:  "$in",
+	Nin: "$nin",
+}
+
+var sqlOperators = map[Operator]string{
+	Eq:   "=",
+	Ne:   "<>",
+	Gt:   ">",
+	Gte:  ">=",
+	Lt:   "<",
+	Lte:  "<=",
+	In:   "IN",
+	Nin:  "NOT IN",
+	Like: "LIKE",
+}
+
+// Bson returns the Mongo filter. Like matches case-insensitive substrings
+func (f Filter) Bson() bson.D {
+	conditions := make(bson.A, 0, len(f.Conditions))
+	for _, c := range f.Conditions {
+		conditions = append(conditions, c.bson())
+	}
+
+	switch len(conditions) {
+	case 0:
+		return bson.D{}
+	case 1:
+		return conditions[0].(bson.D)
+	default:
+		return bson.D{{Key: "$and", Value: conditions}}
+	}
+}
+
+func (c Condition) bson() bson.D {
+	switch c.Operator {
+	case Eq:
+		return bson.D{{Key: c.Field, Value: c.Values[0]}}
+	case Like:
+		pattern := regexp.QuoteMeta(fmt.Sprint(c.Values[0]))
+		return bson.D{{Key: c.Field, Value: bson.D{{Key: "$regex", Value: pattern}, {Key: "$options", Value: "i"}}}}
+	case In, Nin:
+		return bson.D{{Key: c.Field, Value: bson.D{{Key: mongoOperators[c.Operator], Value: bson.A(c.Values)}}}}
+	default:
+		return bson.D{{Key: c.Field, Value: bson.D{{Key: mongoOperators[c.Operator], Value: c.Values[0]}}}}
+	}
+}
+
+// SQL returns the WHERE clause (without WHERE) with ? placeholders and its
+// arguments, or an empty string without conditions. The fields are mapped to
+// columns with response.SQLColumn. Like matches case-insensitive substrings,
+// as in Bson, whatever the collation of the column
+func (f Filter) SQL(mapper response.ColumnMapper) (string, []any) {
+	clauses := make([]string, 0, len(f.Conditions))
+	var args []any
+
+	for _, c := range f.Conditions {
+		column := response.SQLColumn(mapper, c.Field)
+
+		switch c.Operator {
+		case In, Nin:
+			placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(c.Values)), ", ")
+			clauses = append(clauses, fmt.Sprintf("%s %s (%s)", column, sqlOperators[c.Operator], placeholders))
+			args = append(args, c.Values...)
+		case Like:
+			clauses = append(clauses, fmt.Sprintf("LOWER(%s) LIKE ? ESCAPE '!'", column))
+			args = append(args, "%"+escapeLike(strings.ToLower(fmt.Sprint(c.Values[0])))+"%")
+		default:
+			clauses = append(clauses, fmt.Sprintf("%s %s ?", column, sqlOperators[c.Operator]))
+			args = append(args, c.Values[0])
+		}
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+// escapeLike escapes the wildcards with !, since a backslash is itself
+// an escape character inside MySQL string literals
+func escapeLike(value string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(value)
+}
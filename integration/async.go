@@ -2,20 +2,40 @@ package integration
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"sync"
 	"time"
 
-	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
+	"github.com/javiorfo/go-microservice-lib/tracing"
 	"github.com/javiorfo/nilo"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const asyncTracerName = "AsyncClient"
+
+const (
+	asyncCodeKey    = attribute.Key("async.code")
+	asyncAttemptKey = attribute.Key("async.attempt")
 )
 
 type Async interface {
 	Execute(Request)
 }
 
+// Shutdowner is implemented by the Async clients that can be drained on shutdown
+type Shutdowner interface {
+	// Shutdown rejects new executions and waits for the in-flight ones until ctx is done
+	Shutdown(context.Context) error
+}
+
 type Try int
 type state string
 
@@ -23,6 +43,9 @@ type asyncClient struct {
 	try        Try
 	collection *mongo.Collection
 	client     Client[RawData]
+	mu         sync.Mutex
+	inFlight   sync.WaitGroup
+	closed     bool
 }
 
 func NewAsyncHttpClient(collection *mongo.Collection, try Try) Async {
@@ -30,55 +53,137 @@ func NewAsyncHttpClient(collection *mongo.Collection, try Try) Async {
 }
 
 func (a *asyncClient) Execute(r Request) {
+	// the closed check and Add are atomic with Shutdown, so Add never races with Wait
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		tracing.Logger().WarnContext(r.ctx, "async client shut down, execution rejected", "url", r.url)
+		return
+	}
+	a.inFlight.Add(1)
+	a.mu.Unlock()
+
+	// the execution is linked to the originating span but outlives the
+	// request, whose context may be cancelled once the handler returns
+	origin := trace.SpanContextFromContext(r.ctx)
+	detached := context.WithoutCancel(r.ctx)
+
 	go func() {
+		defer a.inFlight.Done()
+
 		code := uuid.NewString()
+		startOptions := []trace.SpanStartOption{
+			trace.WithNewRoot(),
+			trace.WithAttributes(asyncCodeKey.String(code), semconv.URLFull(r.url)),
+		}
+		if origin.IsValid() {
+			startOptions = append(startOptions, trace.WithLinks(trace.Link{SpanContext: origin}))
+		}
+
+		ctx, span := otel.Tracer(asyncTracerName).Start(detached, "async execution", startOptions...)
+		defer span.End()
+
+		logger := tracing.Logger().With("async", code)
 		defer func() {
-			log.Infof("async %s execution terminated", code)
-			if r := recover(); r != nil {
-				log.Errorf("async %s. Panic: %v", code, r)
+			logger.InfoContext(ctx, "async execution terminated")
+			if p := recover(); p != nil {
+				logger.ErrorContext(ctx, "async panic", "panic", p)
+				recordAsyncExecution(ctx, "PANIC")
 			}
 		}()
 
-		model, err := a.create(r.ctx, newAsyncModel(code, r.url, r.body))
+		model, err := a.create(ctx, newAsyncModel(code, span.SpanContext().TraceID().String(), r.url, r.body))
 		if err != nil {
-			log.Errorf("async %s. Error creating mongo model: %v", code, err)
+			logger.ErrorContext(ctx, "error creating mongo model", "error", err)
 			return
 		}
-		log.Infof("async %s. Created", code)
+		logger.InfoContext(ctx, "async execution created")
 
 		for i := range a.try {
-			log.Infof("async %s. Try %d: %s", code, i+1, r.url)
-			resp, err := a.client.Send(r)
+			attempt := int(i) + 1
+			succeeded, err := a.attempt(ctx, logger, r, model, attempt)
+			if err != nil {
+				// the failed attempt could not be saved, so retrying would leave the model stale
+				return
+			}
+			if succeeded {
+				recordAsyncExecution(ctx, "OK")
+				return
+			}
+
+			if i+1 == a.try {
+				logger.ErrorContext(ctx, "async execution failed", "tries", attempt)
+				recordAsyncExecution(ctx, "ERROR")
+				return
+			}
 
-			if err != nil || resp.Error != nil {
-				_, errStr := nilo.Ptr(resp).MapToString(func(r Response[RawData]) string {
-					return r.ErrorToJson().Or("No error response available")
-				}).OrError(nilo.ReturnError(err))
+			time.Sleep(3 * time.Second)
+		}
+	}()
+}
 
-				log.Errorf("async %s. Try %d. Error executing request: %v", code, i+1, errStr)
+// attempt sends the request inside a child span and reports whether it succeeded.
+// The error is returned when a failed attempt cannot be saved
+func (a *asyncClient) attempt(ctx context.Context, logger *slog.Logger, r Request, model asyncModel, attempt int) (bool, error) {
+	ctx, span := otel.Tracer(asyncTracerName).Start(ctx, "async attempt",
+		trace.WithAttributes(asyncAttemptKey.Int(attempt)))
+	defer span.End()
+
+	logger.InfoContext(ctx, "async try", "try", attempt, "url", r.url)
+	r.ctx = ctx
+	resp, err := a.client.Send(r)
+
+	if err != nil || resp.Error != nil {
+		_, errStr := nilo.Ptr(resp).MapToString(func(r Response[RawData]) string {
+			return r.ErrorToJson().Or("No error response available")
+		}).OrError(nilo.ReturnError(err))
+
+		span.SetAttributes(outcomeKey.String("ERROR"))
+		tracing.RecordError(span, errStr)
+		logger.ErrorContext(ctx, "error executing request", "try", attempt, "error", errStr)
+		recordAsyncAttempt(ctx, attempt, "ERROR")
+
+		if err := a.update(ctx, model, "ERROR", errStr.Error()); err != nil {
+			logger.ErrorContext(ctx, "error setting error mongo model", "try", attempt, "error", err)
+			return false, err
+		}
+		return false, nil
+	}
 
-				if err := a.update(r.ctx, model, "ERROR", errStr.Error()); err != nil {
-					log.Errorf("async %s. Try %d. Error setting error mongo model: %v", code, i+1, err)
-					return
-				}
+	span.SetAttributes(outcomeKey.String("OK"))
+	recordAsyncAttempt(ctx, attempt, "OK")
 
-				time.Sleep(3 * time.Second)
-				continue
-			}
+	if err = a.update(ctx, model, "OK", resp.DataToJson().Or("No response available")); err != nil {
+		logger.ErrorContext(ctx, "error updating mongo model", "try", attempt, "error", err)
+	} else {
+		logger.InfoContext(ctx, "async try succeeded", "try", attempt)
+	}
+	return true, nil
+}
 
-			if err = a.update(r.ctx, model, "OK", resp.DataToJson().Or("No response available")); err != nil {
-				log.Errorf("async %s. Try %d. Error updating mongo model: %v", code, i+1, err)
-			} else {
-				log.Infof("async %s. Try %d. Succeded", code, i+1)
-			}
-			return
-		}
+func (a *asyncClient) Shutdown(ctx context.Context) error {
+	a.mu.Lock()
+	a.closed = true
+	a.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		a.inFlight.Wait()
+		close(done)
 	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.Join(errors.New("in-flight async executions abandoned"), ctx.Err())
+	}
 }
 
 type asyncModel struct {
 	ID       primitive.ObjectID `bson:"_id,omitempty"`
 	Code     string             `bson:"code"`
+	TraceID  string             `bson:"traceId,omitempty"`
 	Endpoint string             `bson:"endpoint"`
 	Body     string             `bson:"body"`
 	State    state              `bson:"state"`
@@ -86,10 +191,11 @@ type asyncModel struct {
 	Date     time.Time          `bson:"date"`
 }
 
-func newAsyncModel(code, endpoint string, body *[]byte) asyncModel {
+func newAsyncModel(code, traceID, endpoint string, body *[]byte) asyncModel {
 	return asyncModel{
 		ID:       primitive.NewObjectID(),
 		Code:     code,
+		TraceID:  traceID,
 		Endpoint: endpoint,
 		Body:     string(*body),
 		State:    "PROCESSING",
@@ -97,7 +203,7 @@ func newAsyncModel(code, endpoint string, body *[]byte) asyncModel {
 	}
 }
 
-func (a asyncClient) create(ctx context.Context, am asyncModel) (asyncModel, error) {
+func (a *asyncClient) create(ctx context.Context, am asyncModel) (asyncModel, error) {
 	_, err := a.collection.InsertOne(ctx, am)
 	if err != nil {
 		return am, err
@@ -105,7 +211,7 @@ func (a asyncClient) create(ctx context.Context, am asyncModel) (asyncModel, err
 	return am, nil
 }
 
-func (a asyncClient) update(ctx context.Context, am asyncModel, state state, response string) error {
+func (a *asyncClient) update(ctx context.Context, am asyncModel, state state, response string) error {
 	filter := bson.M{"_id": am.ID}
 
 	am.State = state
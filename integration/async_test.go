@@ -2,21 +2,40 @@ package integration
 
 import (
 	"context"
+	"flag"
 	"log"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/javiorfo/go-microservice-lib/tracing/tracingtest"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
 var async Async
+var asyncCollection *mongo.Collection
 
 func TestMain(m *testing.M) {
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(metricReader)))
+
+	// the short tests do not need MongoDB, which runs in Docker
+	flag.Parse()
+	if testing.Short() {
+		os.Exit(m.Run())
+	}
+
 	ctx := context.Background()
 
 	req := testcontainers.ContainerRequest{
@@ -53,16 +72,25 @@ func TestMain(m *testing.M) {
 	if err := client.Ping(ctx, nil); err != nil {
 		log.Fatalf("Failed to ping MongoDB: %s", err)
 	}
-	collection := client.Database("testdb").Collection("dummies")
+	asyncCollection = client.Database("testdb").Collection("dummies")
 
-	async = NewAsyncHttpClient(collection, 3)
+	async = NewAsyncHttpClient(asyncCollection, 3)
 
 	code := m.Run()
 
 	os.Exit(code)
 }
 
+// requireMongo skips the tests of the async client in short mode
+func requireMongo(t *testing.T) {
+	t.Helper()
+	if asyncCollection == nil {
+		t.Skip("MongoDB is not started in short mode")
+	}
+}
+
 func TestAsync(t *testing.T) {
+	requireMongo(t)
 	ctx := context.Background()
 	request := NewRequest(ctx, "https://jsonplaceholder.typicode.com/posts",
 		WithMethod(http.MethodPost),
@@ -77,6 +105,7 @@ func TestAsync(t *testing.T) {
 }
 
 func TestAsyncError(t *testing.T) {
+	requireMongo(t)
 	ctx := context.Background()
 	request := NewRequest(ctx, "https://jsonplacehol",
 		WithMethod(http.MethodPost),
@@ -92,3 +121,77 @@ func TestAsyncError(t *testing.T) {
 	// Wait 20 secs to check the retries
 	time.Sleep(20 * time.Second)
 }
+
+func TestAsyncSpans(t *testing.T) {
+	requireMongo(t)
+	recorder := tracingtest.Install(t)
+	collectMetrics(t)
+
+	tries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tries++
+		w.Header().Set("Content-Type", "application/json")
+		if tries == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"unavailable"}`))
+			return
+		}
+		w.Write([]byte(`{"userId":100}`))
+	}))
+	defer server.Close()
+
+	ctx, origin := otel.Tracer("test").Start(t.Context(), "origin")
+	origin.End()
+
+	client := NewAsyncHttpClient(asyncCollection, 2)
+	client.Execute(NewRequest(ctx, server.URL, WithMethod(http.MethodPost), WithBody(data{UserId: 100}), WithJsonHeaders()))
+	if err := client.(Shutdowner).Shutdown(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	execution := recorder.Span(t, "async execution").IsRoot().HasAttribute(semconv.URLFullKey, server.URL).ReadOnlySpan()
+	links := execution.Links()
+	if len(links) != 1 || links[0].SpanContext.SpanID() != origin.SpanContext().SpanID() {
+		t.Errorf("Execution must be linked to the origin span. Got %v", links)
+	}
+
+	var attempts []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Spans() {
+		if span.Name() == "async attempt" {
+			attempts = append(attempts, span)
+		}
+	}
+	if len(attempts) != 2 {
+		t.Fatalf("Expected 2 attempt spans. Got %d", len(attempts))
+	}
+	for i, attempt := range attempts {
+		if attempt.Parent().SpanID() != execution.SpanContext().SpanID() {
+			t.Errorf("Attempt %d must be a child of the execution", i+1)
+		}
+	}
+	if attempts[0].Status().Code != codes.Error || attempts[1].Status().Code == codes.Error {
+		t.Errorf("Only the first attempt must fail. Got %v and %v", attempts[0].Status(), attempts[1].Status())
+	}
+
+	var model asyncModel
+	if err := asyncCollection.FindOne(t.Context(), bson.M{"traceId": execution.SpanContext().TraceID().String()}).Decode(&model); err != nil {
+		t.Fatalf("Model must be stored with the execution trace ID: %v", err)
+	}
+	if model.State != "OK" {
+		t.Errorf("State must be OK. Got %s", model.State)
+	}
+
+	rm := collectMetrics(t)
+	if n := sum(rm, "async.attempts", attribute.Int("attempt", 1), outcomeKey.String("ERROR")); n != 1 {
+		t.Errorf("Expected 1 failed first attempt. Got %d", n)
+	}
+	if n := sum(rm, "async.attempts", attribute.Int("attempt", 2), outcomeKey.String("OK")); n != 1 {
+		t.Errorf("Expected 1 successful second attempt. Got %d", n)
+	}
+	if n := sum(rm, "async.retries"); n != 1 {
+		t.Errorf("Expected 1 retry. Got %d", n)
+	}
+	if n := sum(rm, "async.executions", outcomeKey.String("OK")); n != 1 {
+		t.Errorf("Expected 1 successful execution. Got %d", n)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/javiorfo/go-microservice-lib/tracing"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
@@ -41,7 +42,7 @@ func (c client[T]) Send(req Request) (*Response[T], error) {
 		bodyBuffer = bytes.NewBuffer(*req.body)
 	}
 
-	call, err := http.NewRequestWithContext(req.ctx, req.method, req.url, bodyBuffer)
+	call, err := http.NewRequestWithContext(tracing.OutgoingBaggage(req.ctx), req.method, req.url, bodyBuffer)
 	if err != nil {
 		return nil, err
 	}
@@ -50,10 +51,13 @@ func (c client[T]) Send(req Request) (*Response[T], error) {
 		call.Header.Set(k, v)
 	}
 
+	start := time.Now()
 	resp, err := c.client.Do(call)
 	if err != nil {
+		recordClientRequest(req.ctx, call, 0, err, start)
 		return nil, err
 	}
+	recordClientRequest(req.ctx, call, resp.StatusCode, nil, start)
 
 	defer resp.Body.Close()
 
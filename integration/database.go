@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/javiorfo/go-microservice-lib/integration/mongomonitor"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -27,7 +28,7 @@ func (db DBDataConnection) Connect() (context.CancelFunc, error) {
 		db.Host,
 		db.Port)
 
-	clientOptions := options.Client().ApplyURI(dsn)
+	clientOptions := options.Client().ApplyURI(dsn).SetMonitor(mongomonitor.NewCommandMonitor())
 
 	client, err := mongo.Connect(ctx, clientOptions)
 	mongoDB := client.Database(db.DBName)
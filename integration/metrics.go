@@ -0,0 +1,68 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+var (
+	clientMeter = otel.Meter("HttpClient")
+
+	clientRequests, _ = clientMeter.Int64Counter("http.client.requests",
+		metric.WithDescription("Number of HTTP requests sent"),
+		metric.WithUnit("{request}"))
+	clientDuration, _ = clientMeter.Float64Histogram("http.client.request.duration",
+		metric.WithDescription("Duration of HTTP requests sent"),
+		metric.WithUnit("s"))
+
+	asyncMeter = otel.Meter(asyncTracerName)
+
+	asyncAttempts, _ = asyncMeter.Int64Counter("async.attempts",
+		metric.WithDescription("Number of async execution attempts"),
+		metric.WithUnit("{attempt}"))
+	asyncRetries, _ = asyncMeter.Int64Counter("async.retries",
+		metric.WithDescription("Number of async execution retries"),
+		metric.WithUnit("{retry}"))
+	asyncExecutions, _ = asyncMeter.Int64Counter("async.executions",
+		metric.WithDescription("Number of finished async executions by outcome"),
+		metric.WithUnit("{execution}"))
+)
+
+const outcomeKey = attribute.Key("outcome")
+
+func recordClientRequest(ctx context.Context, req *http.Request, statusCode int, err error, start time.Time) {
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+	}
+	if port, err := strconv.Atoi(req.URL.Port()); err == nil {
+		attrs = append(attrs, semconv.ServerPort(port))
+	}
+	if err != nil {
+		attrs = append(attrs, semconv.ErrorTypeOther)
+	} else {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(statusCode))
+	}
+
+	set := metric.WithAttributeSet(attribute.NewSet(attrs...))
+	clientRequests.Add(ctx, 1, set)
+	clientDuration.Record(ctx, time.Since(start).Seconds(), set)
+}
+
+func recordAsyncAttempt(ctx context.Context, attempt int, outcome state) {
+	asyncAttempts.Add(ctx, 1, metric.WithAttributes(attribute.Int("attempt", attempt), outcomeKey.String(string(outcome))))
+	if attempt > 1 {
+		asyncRetries.Add(ctx, 1)
+	}
+}
+
+func recordAsyncExecution(ctx context.Context, outcome state) {
+	asyncExecutions.Add(ctx, 1, metric.WithAttributes(outcomeKey.String(string(outcome))))
+}
@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// metricReader is installed once by TestMain, the instruments are bound to the
+// first MeterProvider. Each collect returns what was recorded since the previous one
+var metricReader = metric.NewManualReader(metric.WithTemporalitySelector(func(metric.InstrumentKind) metricdata.Temporality {
+	return metricdata.DeltaTemporality
+}))
+
+func collectMetrics(t *testing.T) metricdata.ResourceMetrics {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	if err := metricReader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	return rm
+}
+
+// sum adds the points of the counter name having all attrs
+func sum(rm metricdata.ResourceMetrics, name string, attrs ...attribute.KeyValue) int64 {
+	var total int64
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			data, ok := m.Data.(metricdata.Sum[int64])
+			if m.Name != name || !ok {
+				continue
+			}
+		points:
+			for _, point := range data.DataPoints {
+				for _, attr := range attrs {
+					if value, ok := point.Attributes.Value(attr.Key); !ok || value != attr.Value {
+						continue points
+					}
+				}
+				total += point.Value
+			}
+		}
+	}
+	return total
+}
+
+func TestClientMetrics(t *testing.T) {
+	collectMetrics(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewHttpClient[data]()
+	if _, err := client.Send(NewRequest(t.Context(), server.URL, WithJsonHeaders())); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Send(NewRequest(t.Context(), "http://127.0.0.1:1", WithJsonHeaders())); err == nil {
+		t.Fatal("Must be an error")
+	}
+
+	serverURL, _ := url.Parse(server.URL)
+	port, _ := strconv.Atoi(serverURL.Port())
+
+	rm := collectMetrics(t)
+	if n := sum(rm, "http.client.requests", semconv.ServerAddress("127.0.0.1"), semconv.ServerPort(port), semconv.HTTPResponseStatusCode(http.StatusNotFound)); n != 1 {
+		t.Errorf("Expected 1 request answered with 404. Got %d", n)
+	}
+	if n := sum(rm, "http.client.requests", semconv.ServerPort(1), semconv.ErrorTypeOther); n != 1 {
+		t.Errorf("Expected 1 failed request. Got %d", n)
+	}
+}
@@ -0,0 +1,169 @@
+package mongomonitor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	mongoTracerName    = "MongoClient"
+	maxStatementLength = 1024
+	outcomeKey         = attribute.Key("outcome")
+)
+
+var (
+	mongoDuration, _ = otel.Meter(mongoTracerName).Float64Histogram("db.client.operation.duration",
+		metric.WithDescription("Duration of Mongo commands"),
+		metric.WithUnit("s"))
+
+	// command fields that are driver metadata and not part of the statement
+	ignoredCommandFields = []string{"lsid", "$clusterTime", "$db", "$readPreference", "txnNumber", "autocommit", "startTransaction"}
+)
+
+type mongoCommand struct {
+	span  trace.Span
+	attrs []attribute.KeyValue
+}
+
+// NewCommandMonitor creates a Mongo CommandMonitor that starts a child span per
+// command and records its duration. Statement values are replaced by "?"
+func NewCommandMonitor() *event.CommandMonitor {
+	var commands sync.Map
+
+	finish := func(ctx context.Context, evt event.CommandFinishedEvent, failure string) {
+		value, ok := commands.LoadAndDelete(evt.RequestID)
+		if !ok {
+			return
+		}
+		cmd := value.(mongoCommand)
+
+		outcome := "OK"
+		if failure != "" {
+			outcome = "ERROR"
+			cmd.span.RecordError(fmt.Errorf("%s", failure))
+			cmd.span.SetStatus(codes.Error, failure)
+		}
+		cmd.span.End()
+
+		attrs := append(cmd.attrs, outcomeKey.String(outcome))
+		mongoDuration.Record(ctx, evt.Duration.Seconds(), metric.WithAttributes(attrs...))
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			collection, _ := evt.Command.Lookup(evt.CommandName).StringValueOK()
+
+			attrs := []attribute.KeyValue{
+				semconv.DBSystemMongoDB,
+				semconv.DBNamespace(evt.DatabaseName),
+				semconv.DBOperationName(evt.CommandName),
+			}
+			if collection != "" {
+				attrs = append(attrs, semconv.DBCollectionName(collection))
+			}
+
+			name := evt.CommandName
+			if collection != "" {
+				name = fmt.Sprintf("%s %s.%s", evt.CommandName, evt.DatabaseName, collection)
+			}
+
+			_, span := otel.Tracer(mongoTracerName).Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(serverAttributes(evt.ConnectionID)...),
+				trace.WithAttributes(semconv.DBQueryText(sanitizeCommand(evt.Command))),
+			)
+
+			commands.Store(evt.RequestID, mongoCommand{span, attrs})
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			finish(ctx, evt.CommandFinishedEvent, "")
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			finish(ctx, evt.CommandFinishedEvent, evt.Failure)
+		},
+	}
+}
+
+// serverAttributes returns the server address and port of a connection id,
+// which has the form host:port[-n]
+func serverAttributes(connectionID string) []attribute.KeyValue {
+	if i := strings.LastIndex(connectionID, "[-"); i >= 0 {
+		connectionID = connectionID[:i]
+	}
+
+	host, port, err := net.SplitHostPort(connectionID)
+	if err != nil {
+		return []attribute.KeyValue{semconv.ServerAddress(connectionID)}
+	}
+	if port, err := strconv.Atoi(port); err == nil {
+		return []attribute.KeyValue{semconv.ServerAddress(host), semconv.ServerPort(port)}
+	}
+	return []attribute.KeyValue{semconv.ServerAddress(host)}
+}
+
+func sanitizeCommand(command bson.Raw) string {
+	elements, err := command.Elements()
+	if err != nil {
+		return ""
+	}
+
+	statement := make(map[string]any, len(elements))
+	for i, e := range elements {
+		switch {
+		case slices.Contains(ignoredCommandFields, e.Key()):
+		case i == 0 && e.Value().Type == bsontype.String:
+			// the first element is the command name with the collection as value
+			statement[e.Key()] = e.Value().StringValue()
+		default:
+			statement[e.Key()] = sanitizeValue(e.Value())
+		}
+	}
+
+	b, err := json.Marshal(statement)
+	if err != nil {
+		return ""
+	}
+
+	s := string(b)
+	if len(s) > maxStatementLength {
+		return s[:maxStatementLength]
+	}
+	return s
+}
+
+func sanitizeValue(value bson.RawValue) any {
+	switch value.Type {
+	case bsontype.EmbeddedDocument:
+		elements, _ := value.Document().Elements()
+		doc := make(map[string]any, len(elements))
+		for _, e := range elements {
+			doc[e.Key()] = sanitizeValue(e.Value())
+		}
+		return doc
+	case bsontype.Array:
+		values, _ := value.Array().Values()
+		arr := make([]any, 0, len(values))
+		for _, v := range values {
+			arr = append(arr, sanitizeValue(v))
+		}
+		return arr
+	}
+	return "?"
+}
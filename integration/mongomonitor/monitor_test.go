@@ -0,0 +1,44 @@
+package mongomonitor
+
+import (
+	"slices"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func TestSanitizeCommand(t *testing.T) {
+	command, err := bson.Marshal(bson.D{
+		{Key: "find", Value: "dummies"},
+		{Key: "filter", Value: bson.D{{Key: "password", Value: "secret"}, {Key: "age", Value: bson.M{"$gt": 18}}}},
+		{Key: "$db", Value: "testdb"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"filter":{"age":{"$gt":"?"},"password":"?"},"find":"dummies"}`
+	if statement := sanitizeCommand(command); statement != expected {
+		t.Errorf("Statement must be %s. Got %s", expected, statement)
+	}
+
+}
+
+func TestServerAttributes(t *testing.T) {
+	tests := []struct {
+		connectionID string
+		expected     []attribute.KeyValue
+	}{
+		{"localhost:27017[-4]", []attribute.KeyValue{semconv.ServerAddress("localhost"), semconv.ServerPort(27017)}},
+		{"[::1]:27018[-12]", []attribute.KeyValue{semconv.ServerAddress("::1"), semconv.ServerPort(27018)}},
+		{"mongo", []attribute.KeyValue{semconv.ServerAddress("mongo")}},
+	}
+
+	for _, tt := range tests {
+		if got := serverAttributes(tt.connectionID); !slices.Equal(got, tt.expected) {
+			t.Errorf("%s: attributes must be %v. Got %v", tt.connectionID, tt.expected, got)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package errorhandler
+
+import "github.com/gofiber/fiber/v2"
+
+// Handle runs the ErrorHandler of the app on the error of the next handlers,
+// so a middleware sees the real response status. The error is consumed: the
+// middleware must return nil, otherwise the ErrorHandler runs twice. A failing
+// ErrorHandler answers 500
+func Handle(c *fiber.Ctx, err error) {
+	if err == nil {
+		return
+	}
+	if err := c.App().Config().ErrorHandler(c, err); err != nil {
+		_ = c.SendStatus(fiber.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,33 @@
+package errorhandler
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestHandle(t *testing.T) {
+	calls := 0
+	app := fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+		calls++
+		return c.SendStatus(fiber.StatusConflict)
+	}})
+	app.Use(func(c *fiber.Ctx) error {
+		Handle(c, c.Next())
+		if status := c.Response().StatusCode(); status != fiber.StatusConflict {
+			t.Errorf("Status must be 409 after the handler. Got %d", status)
+		}
+		return nil
+	})
+	app.Get("/", func(c *fiber.Ctx) error { return errors.New("conflict") })
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusConflict || calls != 1 {
+		t.Errorf("ErrorHandler must run once. Got %d calls, status %d", calls, resp.StatusCode)
+	}
+}
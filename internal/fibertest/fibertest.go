@@ -0,0 +1,28 @@
+package fibertest
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ParseQuery runs parse in a GET request with query (e.g. "page=2&size=10")
+// and returns its results
+func ParseQuery[T, E any](t testing.TB, query string, parse func(*fiber.Ctx) (T, E)) (T, E) {
+	t.Helper()
+
+	var value T
+	var err E
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		value, err = parse(c)
+		return nil
+	})
+
+	if _, testErr := app.Test(httptest.NewRequest(fiber.MethodGet, "/?"+query, nil)); testErr != nil {
+		t.Fatal(testErr)
+	}
+	return value, err
+}
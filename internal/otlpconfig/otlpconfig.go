@@ -0,0 +1,93 @@
+package otlpconfig
+
+import (
+	"crypto/tls"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Config is the OTLP connection shared by the tracing, metrics and logging options
+type Config struct {
+	Endpoint string
+	Insecure *bool
+	TLS      *tls.Config
+	Headers  map[string]string
+}
+
+// SetInsecure disables TLS on the connection
+func (c *Config) SetInsecure() {
+	insecure := true
+	c.Insecure = &insecure
+}
+
+// SetTLS enables TLS on the connection with the given config
+func (c *Config) SetTLS(config *tls.Config) {
+	insecure := false
+	c.Insecure = &insecure
+	c.TLS = config
+}
+
+// SetHeader adds a header sent on every export
+func (c *Config) SetHeader(name, value string) {
+	if c.Headers == nil {
+		c.Headers = make(map[string]string)
+	}
+	c.Headers[name] = value
+}
+
+// FromEnv sets the endpoint, when it is not configured, from the first of hostVars
+// that is set (e.g. TRACING_HOST). Those endpoints are insecure unless TLS is set.
+// Nothing is set when OTEL_EXPORTER_OTLP_ENDPOINT or the one of signal
+// (e.g. OTEL_EXPORTER_OTLP_TRACES_ENDPOINT) is set, the exporters read them
+func (c *Config) FromEnv(signal string, hostVars ...string) {
+	if c.Endpoint != "" || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_"+signal+"_ENDPOINT") != "" {
+		return
+	}
+
+	for _, name := range hostVars {
+		if c.Endpoint = os.Getenv(name); c.Endpoint != "" {
+			break
+		}
+	}
+	if c.Insecure == nil && c.Endpoint != "" {
+		c.SetInsecure()
+	}
+}
+
+// Options converts the config to the options of an OTLP exporter package
+// (e.g. Options(c, otlptracehttp.WithEndpoint, otlptracehttp.WithHeaders, ...))
+func Options[O any](c Config, endpoint func(string) O, headers func(map[string]string) O, insecure func() O, tlsConfig func(*tls.Config) O) []O {
+	var opts []O
+	if c.Endpoint != "" {
+		opts = append(opts, endpoint(c.Endpoint))
+	}
+	if len(c.Headers) > 0 {
+		opts = append(opts, headers(c.Headers))
+	}
+	if c.Insecure != nil && *c.Insecure {
+		opts = append(opts, insecure())
+	}
+	if c.TLS != nil {
+		opts = append(opts, tlsConfig(c.TLS))
+	}
+	return opts
+}
+
+// Exporters parses the comma separated exporters of env (e.g. OTEL_METRICS_EXPORTER),
+// skipping duplicates and none. Without the variable the defaults are returned
+func Exporters[E ~string](env string, defaults ...E) []E {
+	value := os.Getenv(env)
+	if value == "" {
+		return defaults
+	}
+
+	exporters := make([]E, 0)
+	for name := range strings.SplitSeq(strings.ToLower(value), ",") {
+		exporter := E(strings.TrimSpace(name))
+		if exporter != "none" && !slices.Contains(exporters, exporter) {
+			exporters = append(exporters, exporter)
+		}
+	}
+	return exporters
+}
@@ -0,0 +1,45 @@
+package otlpconfig
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "")
+	t.Setenv("METRICS_HOST", "")
+	t.Setenv("TRACING_HOST", "collector:4318")
+
+	var c Config
+	c.FromEnv("METRICS", "METRICS_HOST", "TRACING_HOST")
+	if c.Endpoint != "collector:4318" || c.Insecure == nil || !*c.Insecure {
+		t.Errorf("TRACING_HOST must be used insecure. Got %+v", c)
+	}
+
+	c = Config{}
+	c.SetTLS(nil)
+	c.FromEnv("METRICS", "METRICS_HOST", "TRACING_HOST")
+	if *c.Insecure {
+		t.Error("TLS must not be replaced by the host variable")
+	}
+
+	t.Setenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "http://otel:4318")
+	c = Config{}
+	c.FromEnv("METRICS", "METRICS_HOST", "TRACING_HOST")
+	if c.Endpoint != "" || c.Insecure != nil {
+		t.Errorf("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT must take precedence. Got %+v", c)
+	}
+}
+
+func TestExporters(t *testing.T) {
+	t.Setenv("OTEL_LOGS_EXPORTER", "")
+	if exporters := Exporters("OTEL_LOGS_EXPORTER", "otlp"); !slices.Equal(exporters, []string{"otlp"}) {
+		t.Errorf("Defaults must be used. Got %v", exporters)
+	}
+
+	t.Setenv("OTEL_LOGS_EXPORTER", "Console, otlp,none,console")
+	if exporters := Exporters[string]("OTEL_LOGS_EXPORTER"); !slices.Equal(exporters, []string{"console", "otlp"}) {
+		t.Errorf("Exporters must be console and otlp. Got %v", exporters)
+	}
+}
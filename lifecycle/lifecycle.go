@@ -0,0 +1,122 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/javiorfo/go-microservice-lib/tracing"
+)
+
+// Step is a shutdown action. If Timeout is zero the Manager timeout is used
+type Step struct {
+	Name    string
+	Timeout time.Duration
+	Stop    func(context.Context) error
+}
+
+type managerOption struct {
+	timeout time.Duration
+	signals []os.Signal
+}
+
+type ManagerOptions func(*managerOption)
+
+// WithTimeout sets the default timeout of every step (10 seconds if not set)
+func WithTimeout(timeout time.Duration) ManagerOptions {
+	return func(o *managerOption) {
+		o.timeout = timeout
+	}
+}
+
+// WithSignals sets the signals waited by Wait (SIGINT and SIGTERM if not set)
+func WithSignals(signals ...os.Signal) ManagerOptions {
+	return func(o *managerOption) {
+		o.signals = signals
+	}
+}
+
+// Manager runs the shutdown steps in the order they were added.
+// The recommended order is: Fiber server, async jobs, Mongo, meter and tracer providers
+type Manager struct {
+	steps   []Step
+	timeout time.Duration
+	signals []os.Signal
+}
+
+func New(options ...ManagerOptions) *Manager {
+	opts := managerOption{
+		timeout: 10 * time.Second,
+		signals: []os.Signal{os.Interrupt, syscall.SIGTERM},
+	}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	return &Manager{timeout: opts.timeout, signals: opts.signals}
+}
+
+// Add appends steps to the shutdown sequence
+func (m *Manager) Add(steps ...Step) *Manager {
+	m.steps = append(m.steps, steps...)
+	return m
+}
+
+// Wait blocks until one of the signals is received and then runs Shutdown
+func (m *Manager) Wait() error {
+	ctx, stop := signal.NotifyContext(context.Background(), m.signals...)
+	defer stop()
+
+	<-ctx.Done()
+	tracing.Logger().Info("shutdown signal received")
+
+	return m.Shutdown(context.Background())
+}
+
+// Shutdown runs every step with its own timeout. A failing step does
+// not prevent the next ones; all the errors are returned joined
+func (m *Manager) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	for _, step := range m.steps {
+		timeout := step.Timeout
+		if timeout == 0 {
+			timeout = m.timeout
+		}
+
+		start := time.Now()
+		tracing.Logger().Info("shutdown step started", "step", step.Name, "timeout", timeout.String())
+
+		if err := runStep(ctx, step, timeout); err != nil {
+			tracing.Logger().Error("shutdown step failed", "step", step.Name, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", step.Name, err))
+			continue
+		}
+
+		tracing.Logger().Info("shutdown step finished", "step", step.Name, "elapsed", time.Since(start).String())
+	}
+
+	return errors.Join(errs...)
+}
+
+func runStep(ctx context.Context, step Step, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- step.Stop(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
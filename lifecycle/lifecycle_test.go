@@ -0,0 +1,66 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/javiorfo/go-microservice-lib/integration"
+)
+
+func TestShutdownOrderAndTimeouts(t *testing.T) {
+	var executed []string
+	step := func(name string, err error) Step {
+		return Func(name, func(ctx context.Context) error {
+			executed = append(executed, name)
+			return err
+		})
+	}
+
+	slow := Step{Name: "slow", Timeout: 10 * time.Millisecond, Stop: func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}}
+
+	failure := errors.New("failure")
+	err := New().Add(step("server", nil), slow, step("async", failure), step("tracer", nil)).Shutdown(t.Context())
+
+	if !slices.Equal(executed, []string{"server", "async", "tracer"}) {
+		t.Errorf("Steps must run in order. Got %v", executed)
+	}
+
+	if !errors.Is(err, failure) {
+		t.Errorf("Error must contain the step error. Got %v", err)
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Error must contain the slow step deadline. Got %v", err)
+	}
+}
+
+type async struct{}
+
+func (async) Execute(integration.Request) {}
+
+type drainedAsync struct {
+	async
+	drained bool
+}
+
+func (d *drainedAsync) Shutdown(context.Context) error {
+	d.drained = true
+	return nil
+}
+
+func TestAsyncJobs(t *testing.T) {
+	if err := AsyncJobs(async{}).Stop(t.Context()); err != nil {
+		t.Errorf("Async without Shutdown must be skipped. Got %v", err)
+	}
+
+	d := &drainedAsync{}
+	if err := AsyncJobs(d).Stop(t.Context()); err != nil || !d.drained {
+		t.Errorf("Async must be shut down. Got %v", err)
+	}
+}
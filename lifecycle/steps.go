@@ -0,0 +1,52 @@
+package lifecycle
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/integration"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/trace"
+)
+
+// FiberServer stops accepting connections and drains the in-flight requests
+func FiberServer(app *fiber.App) Step {
+	return Step{Name: "fiber server", Stop: app.ShutdownWithContext}
+}
+
+// AsyncJobs rejects new async executions and waits for the in-flight ones.
+// Async clients that are not an integration.Shutdowner are left as they are
+func AsyncJobs(async integration.Async) Step {
+	stop := func(context.Context) error { return nil }
+	if s, ok := async.(integration.Shutdowner); ok {
+		stop = s.Shutdown
+	}
+	return Step{Name: "async jobs", Stop: stop}
+}
+
+// MongoClient disconnects from Mongo
+func MongoClient(client *mongo.Client) Step {
+	return Step{Name: "mongo client", Stop: client.Disconnect}
+}
+
+// MeterProvider flushes and stops the metric exporters
+func MeterProvider(mp *metric.MeterProvider) Step {
+	return Step{Name: "meter provider", Stop: mp.Shutdown}
+}
+
+// LoggerProvider flushes and stops the log exporters
+func LoggerProvider(lp *log.LoggerProvider) Step {
+	return Step{Name: "logger provider", Stop: lp.Shutdown}
+}
+
+// TracerProvider flushes and stops the span exporters
+func TracerProvider(tp *trace.TracerProvider) Step {
+	return Step{Name: "tracer provider", Stop: tp.Shutdown}
+}
+
+// Func creates a step from any shutdown function
+func Func(name string, stop func(context.Context) error) Step {
+	return Step{Name: name, Stop: stop}
+}
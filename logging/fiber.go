@@ -0,0 +1,115 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	fiberlog "github.com/gofiber/fiber/v2/log"
+	"github.com/javiorfo/go-microservice-lib/tracing"
+	"go.opentelemetry.io/otel/sdk/log"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+const (
+	levelTrace = slog.LevelDebug - 4
+	levelFatal = slog.LevelError + 4
+)
+
+var levels = map[fiberlog.Level]slog.Level{
+	fiberlog.LevelTrace: levelTrace,
+	fiberlog.LevelDebug: slog.LevelDebug,
+	fiberlog.LevelInfo:  slog.LevelInfo,
+	fiberlog.LevelWarn:  slog.LevelWarn,
+	fiberlog.LevelError: slog.LevelError,
+	fiberlog.LevelFatal: levelFatal,
+	fiberlog.LevelPanic: levelFatal,
+}
+
+// fiberLogger sends the records of the Fiber log package to tracing.Logger().
+// Fatal exits and Panic panics after logging, like the Fiber default logger.
+// Before exiting the provider is shut down so the pending records are exported
+type fiberLogger struct {
+	ctx      context.Context
+	level    fiberlog.Level
+	provider *log.LoggerProvider
+}
+
+func (l *fiberLogger) log(level fiberlog.Level, msg string, args ...any) {
+	if level < l.level {
+		return
+	}
+
+	tracing.Logger().Log(l.ctx, levels[level], msg, args...)
+
+	switch level {
+	case fiberlog.LevelFatal:
+		l.shutdown()
+		os.Exit(1)
+	case fiberlog.LevelPanic:
+		panic(msg)
+	}
+}
+
+func (l *fiberLogger) shutdown() {
+	if l.provider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	_ = l.provider.Shutdown(ctx)
+}
+
+func (l *fiberLogger) Trace(v ...any) { l.log(fiberlog.LevelTrace, fmt.Sprint(v...)) }
+func (l *fiberLogger) Debug(v ...any) { l.log(fiberlog.LevelDebug, fmt.Sprint(v...)) }
+func (l *fiberLogger) Info(v ...any)  { l.log(fiberlog.LevelInfo, fmt.Sprint(v...)) }
+func (l *fiberLogger) Warn(v ...any)  { l.log(fiberlog.LevelWarn, fmt.Sprint(v...)) }
+func (l *fiberLogger) Error(v ...any) { l.log(fiberlog.LevelError, fmt.Sprint(v...)) }
+func (l *fiberLogger) Fatal(v ...any) { l.log(fiberlog.LevelFatal, fmt.Sprint(v...)) }
+func (l *fiberLogger) Panic(v ...any) { l.log(fiberlog.LevelPanic, fmt.Sprint(v...)) }
+
+func (l *fiberLogger) Tracef(format string, v ...any) {
+	l.log(fiberlog.LevelTrace, fmt.Sprintf(format, v...))
+}
+func (l *fiberLogger) Debugf(format string, v ...any) {
+	l.log(fiberlog.LevelDebug, fmt.Sprintf(format, v...))
+}
+func (l *fiberLogger) Infof(format string, v ...any) {
+	l.log(fiberlog.LevelInfo, fmt.Sprintf(format, v...))
+}
+func (l *fiberLogger) Warnf(format string, v ...any) {
+	l.log(fiberlog.LevelWarn, fmt.Sprintf(format, v...))
+}
+func (l *fiberLogger) Errorf(format string, v ...any) {
+	l.log(fiberlog.LevelError, fmt.Sprintf(format, v...))
+}
+func (l *fiberLogger) Fatalf(format string, v ...any) {
+	l.log(fiberlog.LevelFatal, fmt.Sprintf(format, v...))
+}
+func (l *fiberLogger) Panicf(format string, v ...any) {
+	l.log(fiberlog.LevelPanic, fmt.Sprintf(format, v...))
+}
+
+func (l *fiberLogger) Tracew(msg string, kv ...any) { l.log(fiberlog.LevelTrace, msg, kv...) }
+func (l *fiberLogger) Debugw(msg string, kv ...any) { l.log(fiberlog.LevelDebug, msg, kv...) }
+func (l *fiberLogger) Infow(msg string, kv ...any)  { l.log(fiberlog.LevelInfo, msg, kv...) }
+func (l *fiberLogger) Warnw(msg string, kv ...any)  { l.log(fiberlog.LevelWarn, msg, kv...) }
+func (l *fiberLogger) Errorw(msg string, kv ...any) { l.log(fiberlog.LevelError, msg, kv...) }
+func (l *fiberLogger) Fatalw(msg string, kv ...any) { l.log(fiberlog.LevelFatal, msg, kv...) }
+func (l *fiberLogger) Panicw(msg string, kv ...any) { l.log(fiberlog.LevelPanic, msg, kv...) }
+
+func (l *fiberLogger) SetLevel(level fiberlog.Level) {
+	l.level = level
+}
+
+// SetOutput is ignored, the output is the one of tracing.Logger()
+func (l *fiberLogger) SetOutput(io.Writer) {}
+
+// WithContext correlates the records with the span in ctx
+func (l *fiberLogger) WithContext(ctx context.Context) fiberlog.CommonLogger {
+	return &fiberLogger{ctx: ctx, level: l.level, provider: l.provider}
+}
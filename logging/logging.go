@@ -0,0 +1,172 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	fiberlog "github.com/gofiber/fiber/v2/log"
+	"github.com/javiorfo/go-microservice-lib/internal/otlpconfig"
+	"github.com/javiorfo/go-microservice-lib/tracing"
+	"go.opentelemetry.io/contrib/bridges/otelslog"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/sdk/log"
+)
+
+// Exporter identifies where the log records are sent
+type Exporter string
+
+const (
+	OtlpExporter    Exporter = "otlp"
+	ConsoleExporter Exporter = "console"
+)
+
+type loggingOption struct {
+	exporters []Exporter
+	otlp      otlpconfig.Config
+	writer    io.Writer
+	resource  []tracing.ResourceOptions
+}
+
+type LoggingOptions func(*loggingOption)
+
+// WithExporters sets the log exporters. If not set, OTEL_LOGS_EXPORTER
+// is honored (comma separated), falling back to OTLP
+func WithExporters(exporters ...Exporter) LoggingOptions {
+	return func(o *loggingOption) {
+		o.exporters = exporters
+	}
+}
+
+// WithEndpoint sets the OTLP collector endpoint (host:port).
+// If not set, LOGGING_HOST, TRACING_HOST or the OTEL_EXPORTER_OTLP_* variables are used
+func WithEndpoint(endpoint string) LoggingOptions {
+	return func(o *loggingOption) {
+		o.otlp.Endpoint = endpoint
+	}
+}
+
+// WithInsecure disables TLS on the OTLP connection
+func WithInsecure() LoggingOptions {
+	return func(o *loggingOption) {
+		o.otlp.SetInsecure()
+	}
+}
+
+// WithHeader adds a header (e.g. Authorization) sent on every OTLP export
+func WithHeader(name, value string) LoggingOptions {
+	return func(o *loggingOption) {
+		o.otlp.SetHeader(name, value)
+	}
+}
+
+// WithWriter sets where the console exporter writes. Default os.Stdout
+func WithWriter(w io.Writer) LoggingOptions {
+	return func(o *loggingOption) {
+		o.writer = w
+	}
+}
+
+// WithResourceOptions sets the service version, environment and custom resource attributes
+func WithResourceOptions(options ...tracing.ResourceOptions) LoggingOptions {
+	return func(o *loggingOption) {
+		o.resource = append(o.resource, options...)
+	}
+}
+
+// StartLogging configures the global LoggerProvider. The records of tracing.Logger()
+// and of the Fiber log package are still written to the console and also exported,
+// correlated with the span in their context. By default records are sent by OTLP/HTTP
+func StartLogging(appName string, options ...LoggingOptions) (*log.LoggerProvider, error) {
+	opts := newLoggingOption(options...)
+
+	res, err := tracing.NewResource(context.Background(), appName, opts.resource...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating resource: %w", err)
+	}
+
+	providerOptions := []log.LoggerProviderOption{
+		log.WithResource(res),
+	}
+
+	for _, exporter := range opts.exporters {
+		switch exporter {
+		case OtlpExporter:
+			otlpExporter, err := otlploghttp.New(context.Background(), opts.httpOptions()...)
+			if err != nil {
+				return nil, fmt.Errorf("error creating otlp exporter: %w", err)
+			}
+			providerOptions = append(providerOptions, log.WithProcessor(log.NewBatchProcessor(otlpExporter)))
+		case ConsoleExporter:
+			consoleExporter, err := stdoutlog.New(stdoutlog.WithWriter(opts.writer))
+			if err != nil {
+				return nil, fmt.Errorf("error creating console exporter: %w", err)
+			}
+			providerOptions = append(providerOptions, log.WithProcessor(log.NewSimpleProcessor(consoleExporter)))
+		default:
+			return nil, fmt.Errorf("unknown exporter %q", exporter)
+		}
+	}
+
+	loggerProvider := log.NewLoggerProvider(providerOptions...)
+	global.SetLoggerProvider(loggerProvider)
+
+	bridge := otelslog.NewHandler(appName, otelslog.WithLoggerProvider(loggerProvider))
+	tracing.SetLogger(slog.New(&handler{tracing.Logger().Handler(), bridge}))
+	fiberlog.SetLogger(&fiberLogger{ctx: context.Background(), provider: loggerProvider})
+
+	return loggerProvider, nil
+}
+
+// handler writes the records with the console handler and, when it is
+// enabled for their level, exports them through the OTel bridge. A failed
+// export does not stop the record from being written
+type handler struct {
+	console slog.Handler
+	bridge  slog.Handler
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.console.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	exported := record.Clone()
+	exported.AddAttrs(tracing.LogFields(ctx)...)
+	return errors.Join(h.console.Handle(ctx, record), h.bridge.Handle(ctx, exported))
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &handler{h.console.WithAttrs(attrs), h.bridge.WithAttrs(attrs)}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{h.console.WithGroup(name), h.bridge.WithGroup(name)}
+}
+
+func newLoggingOption(options ...LoggingOptions) loggingOption {
+	opts := loggingOption{
+		writer: os.Stdout,
+	}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	if opts.exporters == nil {
+		opts.exporters = otlpconfig.Exporters("OTEL_LOGS_EXPORTER", OtlpExporter)
+	}
+	opts.otlp.FromEnv("LOGS", "LOGGING_HOST", "TRACING_HOST")
+
+	return opts
+}
+
+func (o loggingOption) httpOptions() []otlploghttp.Option {
+	return otlpconfig.Options(o.otlp, otlploghttp.WithEndpoint, otlploghttp.WithHeaders,
+		otlploghttp.WithInsecure, otlploghttp.WithTLSClientConfig)
+}
@@ -0,0 +1,89 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	fiberlog "github.com/gofiber/fiber/v2/log"
+	"github.com/javiorfo/go-microservice-lib/tracing"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+func TestStartLogging(t *testing.T) {
+	logger, provider := tracing.Logger(), global.GetLoggerProvider()
+	t.Cleanup(func() {
+		tracing.SetLogger(logger)
+		global.SetLoggerProvider(provider)
+		fiberlog.SetLogger(fiberlog.DefaultLogger())
+	})
+
+	var buf bytes.Buffer
+	lp, err := StartLogging("test", WithExporters(ConsoleExporter), WithWriter(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lp.Shutdown(context.Background())
+
+	tracing.Logger().Info("from slog", "key", "value")
+	fiberlog.Warnw("from fiber", "status", 404)
+
+	if err := lp.ForceFlush(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var bodies []string
+	decoder := json.NewDecoder(&buf)
+	for {
+		var record struct {
+			Body     struct{ Value string }
+			Severity int
+		}
+		if err := decoder.Decode(&record); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		bodies = append(bodies, record.Body.Value)
+	}
+
+	if len(bodies) != 2 || bodies[0] != "from slog" || bodies[1] != "from fiber" {
+		t.Errorf("Records from slog and fiber must be exported. Got %v", bodies)
+	}
+}
+
+func TestExportersFromEnv(t *testing.T) {
+	t.Setenv("OTEL_LOGS_EXPORTER", "console, otlp,none")
+
+	exporters := newLoggingOption().exporters
+	if len(exporters) != 2 || exporters[0] != ConsoleExporter || exporters[1] != OtlpExporter {
+		t.Errorf("Exporters must be console and otlp. Got %v", exporters)
+	}
+}
+
+type failingHandler struct {
+	slog.Handler
+}
+
+func (failingHandler) Handle(context.Context, slog.Record) error {
+	return errors.New("export failed")
+}
+
+func TestHandlerExportError(t *testing.T) {
+	var buf bytes.Buffer
+	h := &handler{slog.NewJSONHandler(&buf, nil), failingHandler{}}
+
+	err := h.Handle(context.Background(), slog.NewRecord(time.Now(), slog.LevelInfo, "written", 0))
+	if err == nil || err.Error() != "export failed" {
+		t.Errorf("Export error must be returned. Got %v", err)
+	}
+	if !strings.Contains(buf.String(), "written") {
+		t.Errorf("Record must be written to the console. Got %s", buf.String())
+	}
+}
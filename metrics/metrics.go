@@ -0,0 +1,177 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/javiorfo/go-microservice-lib/internal/otlpconfig"
+	"github.com/javiorfo/go-microservice-lib/tracing"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+)
+
+// Exporter identifies where the metrics are sent
+type Exporter string
+
+const (
+	OtlpExporter       Exporter = "otlp"
+	PrometheusExporter Exporter = "prometheus"
+)
+
+type metricsOption struct {
+	exporters []Exporter
+	otlp      otlpconfig.Config
+	interval  time.Duration
+	registry  *prometheus.Registry
+	resource  []tracing.ResourceOptions
+}
+
+type MetricsOptions func(*metricsOption)
+
+// WithExporters sets the metric exporters. If not set, OTEL_METRICS_EXPORTER
+// is honored (comma separated), falling back to OTLP and Prometheus
+func WithExporters(exporters ...Exporter) MetricsOptions {
+	return func(o *metricsOption) {
+		o.exporters = exporters
+	}
+}
+
+// WithEndpoint sets the OTLP collector endpoint (host:port).
+// If not set, METRICS_HOST, TRACING_HOST or the OTEL_EXPORTER_OTLP_* variables are used
+func WithEndpoint(endpoint string) MetricsOptions {
+	return func(o *metricsOption) {
+		o.otlp.Endpoint = endpoint
+	}
+}
+
+// WithInsecure disables TLS on the OTLP connection
+func WithInsecure() MetricsOptions {
+	return func(o *metricsOption) {
+		o.otlp.SetInsecure()
+	}
+}
+
+// WithHeader adds a header (e.g. Authorization) sent on every OTLP export
+func WithHeader(name, value string) MetricsOptions {
+	return func(o *metricsOption) {
+		o.otlp.SetHeader(name, value)
+	}
+}
+
+// WithInterval sets how often the metrics are pushed by OTLP
+func WithInterval(interval time.Duration) MetricsOptions {
+	return func(o *metricsOption) {
+		o.interval = interval
+	}
+}
+
+// WithRegistry sets the Prometheus registry the metrics are exposed on
+// (see PrometheusHandlerFor). If not set, every StartMetrics creates its own
+func WithRegistry(registry *prometheus.Registry) MetricsOptions {
+	return func(o *metricsOption) {
+		o.registry = registry
+	}
+}
+
+// WithResourceOptions sets the service version, environment and custom resource attributes
+func WithResourceOptions(options ...tracing.ResourceOptions) MetricsOptions {
+	return func(o *metricsOption) {
+		o.resource = append(o.resource, options...)
+	}
+}
+
+// registry is the one of the last StartMetrics, served by PrometheusHandler
+var registry atomic.Pointer[prometheus.Registry]
+
+func init() {
+	registry.Store(prometheus.NewRegistry())
+}
+
+// StartMetrics configures the global MeterProvider. By default metrics are
+// pushed by OTLP/HTTP and exposed for Prometheus scraping with PrometheusHandler
+func StartMetrics(appName string, options ...MetricsOptions) (*metric.MeterProvider, error) {
+	opts := newMetricsOption(options...)
+
+	res, err := tracing.NewResource(context.Background(), appName, opts.resource...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating resource: %w", err)
+	}
+
+	providerOptions := []metric.Option{
+		metric.WithResource(res),
+	}
+
+	for _, exporter := range opts.exporters {
+		switch exporter {
+		case OtlpExporter:
+			otlpExporter, err := otlpmetrichttp.New(context.Background(), opts.httpOptions()...)
+			if err != nil {
+				return nil, fmt.Errorf("error creating otlp exporter: %w", err)
+			}
+			var readerOptions []metric.PeriodicReaderOption
+			if opts.interval > 0 {
+				readerOptions = append(readerOptions, metric.WithInterval(opts.interval))
+			}
+			providerOptions = append(providerOptions, metric.WithReader(metric.NewPeriodicReader(otlpExporter, readerOptions...)))
+		case PrometheusExporter:
+			if opts.registry == nil {
+				opts.registry = prometheus.NewRegistry()
+			}
+			promExporter, err := otelprometheus.New(otelprometheus.WithRegisterer(opts.registry))
+			if err != nil {
+				return nil, fmt.Errorf("error creating prometheus exporter: %w", err)
+			}
+			registry.Store(opts.registry)
+			providerOptions = append(providerOptions, metric.WithReader(promExporter))
+		default:
+			return nil, fmt.Errorf("unknown exporter %q", exporter)
+		}
+	}
+
+	meterProvider := metric.NewMeterProvider(providerOptions...)
+	otel.SetMeterProvider(meterProvider)
+
+	return meterProvider, nil
+}
+
+// PrometheusHandler serves the metrics of the last StartMetrics in Prometheus
+// text format (e.g. app.Get("/metrics", ...))
+func PrometheusHandler() fiber.Handler {
+	return adaptor.HTTPHandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		promhttp.HandlerFor(registry.Load(), promhttp.HandlerOpts{}).ServeHTTP(w, r)
+	})
+}
+
+// PrometheusHandlerFor serves the metrics of the registry set with WithRegistry,
+// for applications running several MeterProviders
+func PrometheusHandlerFor(registry *prometheus.Registry) fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+}
+
+func newMetricsOption(options ...MetricsOptions) metricsOption {
+	opts := metricsOption{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	if opts.exporters == nil {
+		opts.exporters = otlpconfig.Exporters("OTEL_METRICS_EXPORTER", OtlpExporter, PrometheusExporter)
+	}
+	opts.otlp.FromEnv("METRICS", "METRICS_HOST", "TRACING_HOST")
+
+	return opts
+}
+
+func (o metricsOption) httpOptions() []otlpmetrichttp.Option {
+	return otlpconfig.Options(o.otlp, otlpmetrichttp.WithEndpoint, otlpmetrichttp.WithHeaders,
+		otlpmetrichttp.WithInsecure, otlpmetrichttp.WithTLSClientConfig)
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+)
+
+func TestStartMetricsTwice(t *testing.T) {
+	provider := otel.GetMeterProvider()
+	t.Cleanup(func() { otel.SetMeterProvider(provider) })
+
+	for range 2 {
+		mp, err := StartMetrics("test", WithExporters(PrometheusExporter))
+		if err != nil {
+			t.Fatalf("StartMetrics must not fail when called again: %v", err)
+		}
+		defer mp.Shutdown(context.Background())
+	}
+
+	custom := prometheus.NewRegistry()
+	mp, err := StartMetrics("custom", WithExporters(PrometheusExporter), WithRegistry(custom))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mp.Shutdown(context.Background())
+
+	counter, _ := mp.Meter("test").Int64Counter("orders")
+	counter.Add(t.Context(), 1)
+
+	app := fiber.New()
+	app.Get("/metrics", PrometheusHandler())
+	app.Get("/custom", PrometheusHandlerFor(custom))
+
+	for _, path := range []string{"/metrics", "/custom"} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if !strings.Contains(string(body), "orders_total") {
+			t.Errorf("%s must serve the counter. Got %s", path, body)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/internal/errorhandler"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+type middlewareOption struct {
+	excludedPaths []string
+}
+
+type MiddlewareOptions func(*middlewareOption)
+
+// WithExcludedPaths skips the metrics for the given request paths (e.g. /health, /metrics)
+func WithExcludedPaths(paths ...string) MiddlewareOptions {
+	return func(o *middlewareOption) {
+		o.excludedPaths = append(o.excludedPaths, paths...)
+	}
+}
+
+// Middleware records request rate, errors and duration by route and status.
+// Errors are handled here by the app ErrorHandler and not returned
+func Middleware(options ...MiddlewareOptions) fiber.Handler {
+	opts := middlewareOption{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	meter := otel.Meter("FiberServer")
+	requests, _ := meter.Int64Counter("http.server.requests",
+		metric.WithDescription("Number of HTTP requests"),
+		metric.WithUnit("{request}"))
+	errors, _ := meter.Int64Counter("http.server.errors",
+		metric.WithDescription("Number of HTTP requests answered with 5xx"),
+		metric.WithUnit("{request}"))
+	duration, _ := meter.Float64Histogram("http.server.request.duration",
+		metric.WithDescription("Duration of HTTP requests"),
+		metric.WithUnit("s"))
+
+	return func(c *fiber.Ctx) error {
+		if slices.Contains(opts.excludedPaths, c.Path()) {
+			return c.Next()
+		}
+
+		start := time.Now()
+
+		errorhandler.Handle(c, c.Next())
+
+		status := c.Response().StatusCode()
+		attrs := metric.WithAttributeSet(attribute.NewSet(
+			semconv.HTTPRequestMethodKey.String(c.Method()),
+			semconv.HTTPRoute(c.Route().Path),
+			semconv.HTTPResponseStatusCode(status),
+		))
+
+		ctx := c.UserContext()
+		requests.Add(ctx, 1, attrs)
+		duration.Record(ctx, time.Since(start).Seconds(), attrs)
+		if status >= http.StatusInternalServerError {
+			errors.Add(ctx, 1, attrs)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMiddleware(t *testing.T) {
+	provider := otel.GetMeterProvider()
+	t.Cleanup(func() { otel.SetMeterProvider(provider) })
+
+	reader := metric.NewManualReader()
+	otel.SetMeterProvider(metric.NewMeterProvider(metric.WithReader(reader)))
+
+	app := fiber.New()
+	app.Use(Middleware(WithExcludedPaths("/health")))
+	app.Get("/health", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Get("/items/:id", func(c *fiber.Ctx) error { return c.SendString(c.Params("id")) })
+	app.Get("/fail", func(c *fiber.Ctx) error { return fiber.ErrInternalServerError })
+
+	for _, path := range []string{"/health", "/items/1", "/items/2", "/fail"} {
+		if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(t.Context(), &rm); err != nil {
+		t.Fatal(err)
+	}
+
+	totals := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range sum.DataPoints {
+					totals[m.Name] += dp.Value
+				}
+			}
+		}
+	}
+
+	if totals["http.server.requests"] != 3 {
+		t.Errorf("Expected 3 requests. Got %d", totals["http.server.requests"])
+	}
+	if totals["http.server.errors"] != 1 {
+		t.Errorf("Expected 1 error. Got %d", totals["http.server.errors"])
+	}
+}
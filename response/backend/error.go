@@ -1,8 +1,6 @@
 package backend
 
 import (
-	"net/http"
-
 	"github.com/gofiber/fiber/v2"
 	"github.com/javiorfo/go-microservice-lib/response"
 	"go.opentelemetry.io/otel/trace"
@@ -14,28 +12,23 @@ type Error interface {
 	ToResponse(c *fiber.Ctx) error
 }
 
+// InternalError creates an internal server error caused by err. Its
+// message is only shown to the clients outside production mode
 func InternalError(span trace.Span, err error) error {
-	return InternalMsgError(span, err.Error())
+	return response.WrapError(span, err, response.Error{
+		HttpStatus: response.InternalError.HttpStatus,
+		Code:       response.InternalError.Code,
+		Message:    err.Error(),
+	})
 }
 
 func InternalMsgError(span trace.Span, msg string) error {
-	return response.NewResponseError(span,
-		response.Error{
-			HttpStatus: http.StatusInternalServerError,
-			Code:       response.ErrorCode("INTERNAL-ERROR"),
-			Message:    response.Message(msg),
-		},
-	)
+	return response.InternalServerError(span, msg)
 }
 
+// ParseError renders err like ErrorHandler without custom mappers
 func ParseError(c *fiber.Ctx, err error) error {
-	if fiberErr, ok := err.(Error); ok {
-		return fiberErr.ToResponse(c)
-	}
-
-	return c.Status(http.StatusInternalServerError).JSON(response.Error{
-		HttpStatus: http.StatusInternalServerError,
-		Code:       response.ErrorCode("INTERNAL-ERROR"),
-		Message:    response.Message(err.Error()),
-	})
+	return defaultHandler(c, err)
 }
+
+var defaultHandler = ErrorHandler()
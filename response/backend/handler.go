@@ -0,0 +1,130 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/response"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ErrorMapper converts an error to a response.Error. It returns false
+// when it does not know the error
+type ErrorMapper func(err error) (response.Error, bool)
+
+type handlerOption struct {
+	mappers []ErrorMapper
+}
+
+type HandlerOptions func(*handlerOption)
+
+// WithMapper adds a mapper tried, in order, before the built-in ones
+func WithMapper(mapper ErrorMapper) HandlerOptions {
+	return func(o *handlerOption) {
+		o.mappers = append(o.mappers, mapper)
+	}
+}
+
+// PanicError is returned by Recover with the recovered value
+type PanicError struct {
+	Value any
+}
+
+func (p PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", p.Value)
+}
+
+// ErrorHandler renders any error as a ResponseError (e.g. fiber.Config{ErrorHandler: backend.ErrorHandler()}).
+// Errors wrapping a backend.Error are rendered as they are; the rest go through
+// the custom mappers and then the built-in ones: *fiber.Error keeps its status,
+// context deadlines are 504 and anything else is 500. The errors are wrapped
+// (see response.WrapError), so the 5xx messages are hidden in production mode
+func ErrorHandler(options ...HandlerOptions) fiber.ErrorHandler {
+	opts := handlerOption{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	return func(c *fiber.Ctx, err error) error {
+		span := trace.SpanFromContext(c.UserContext())
+
+		var backendErr Error
+		if errors.As(err, &backendErr) {
+			recordError(span, err, backendErr)
+			return backendErr.ToResponse(c)
+		}
+
+		re := opts.mapError(span, err)
+		recordError(span, err, re)
+		return re.ToResponse(c)
+	}
+}
+
+// Recover turns panics into a PanicError handled by the ErrorHandler.
+// It must be registered after tracing.Middleware to record the stack on the span
+func Recover() fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = PanicError{r}
+				trace.SpanFromContext(c.UserContext()).RecordError(err, trace.WithStackTrace(true))
+			}
+		}()
+		return c.Next()
+	}
+}
+
+func (o handlerOption) mapError(span trace.Span, err error) *response.ResponseError {
+	for _, mapper := range o.mappers {
+		if e, ok := mapper(err); ok {
+			return response.WrapError(span, err, e)
+		}
+	}
+
+	var fiberErr *fiber.Error
+	var panicErr PanicError
+	switch {
+	case errors.As(err, &panicErr):
+		// Recover already recorded the panic with its stack
+		return response.InternalError.Response(span)
+	case errors.As(err, &fiberErr):
+		return response.NewResponseError(span, response.Error{
+			HttpStatus: fiberErr.Code,
+			Code:       statusCode(fiberErr.Code),
+			Message:    fiberErr.Message,
+		})
+	case errors.Is(err, context.DeadlineExceeded):
+		return response.TimeoutError.Wrap(span, err)
+	default:
+		return response.WrapError(span, err, response.Error{
+			HttpStatus: response.InternalError.HttpStatus,
+			Code:       response.InternalError.Code,
+			Message:    err.Error(),
+		})
+	}
+}
+
+// recordError adds the error codes and status to the span, setting it
+// as failed for 5xx with the internal message
+func recordError(span trace.Span, err error, backendErr Error) {
+	var re *response.ResponseError
+	if !errors.As(backendErr, &re) {
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(re.Attributes()...)
+	if re.Get().Status() >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// statusCode converts a status to an error code (e.g. 404 to NOT_FOUND)
+func statusCode(status int) response.ErrorCode {
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
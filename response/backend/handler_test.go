@@ -0,0 +1,137 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/response"
+	"github.com/javiorfo/go-microservice-lib/tracing"
+	"github.com/javiorfo/go-microservice-lib/tracing/tracingtest"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+var errOutOfStock = errors.New("out of stock")
+
+func TestErrorHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler(WithMapper(func(err error) (response.Error, bool) {
+		if errors.Is(err, errOutOfStock) {
+			return response.Error{HttpStatus: fiber.StatusConflict, Code: "OUT_OF_STOCK", Message: err.Error()}, true
+		}
+		return response.Error{}, false
+	}))})
+	app.Use(Recover())
+
+	span := noop.Span{}
+	app.Get("/wrapped", func(c *fiber.Ctx) error {
+		re := response.NewResponseError(span, response.Error{HttpStatus: fiber.StatusBadRequest, Code: "BAD", Message: "bad"})
+		return fmt.Errorf("handling: %w", re)
+	})
+	app.Get("/deadline", func(c *fiber.Ctx) error {
+		return fmt.Errorf("querying: %w", context.DeadlineExceeded)
+	})
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+	app.Get("/stock", func(c *fiber.Ctx) error {
+		return fmt.Errorf("buying: %w", errOutOfStock)
+	})
+	app.Get("/internal", func(c *fiber.Ctx) error {
+		return errors.New("connection refused to db:5432")
+	})
+
+	cases := []struct {
+		path   string
+		status int
+		code   string
+	}{
+		{"/wrapped", fiber.StatusBadRequest, "BAD"},
+		{"/deadline", fiber.StatusGatewayTimeout, "TIMEOUT"},
+		{"/panic", fiber.StatusInternalServerError, "INTERNAL_ERROR"},
+		{"/stock", fiber.StatusConflict, "OUT_OF_STOCK"},
+		{"/missing", fiber.StatusNotFound, "NOT_FOUND"},
+	}
+
+	for _, tc := range cases {
+		status, body := send(t, app, tc.path)
+		if status != tc.status || body.Get().Code != tc.code {
+			t.Errorf("%s must be %d %s. Got %d %+v", tc.path, tc.status, tc.code, status, body)
+		}
+	}
+
+	if _, body := send(t, app, "/internal"); body.Get().Message != "connection refused to db:5432" {
+		t.Errorf("Internal message must be shown outside production. Got %+v", body)
+	}
+
+	response.Configure(response.WithProduction(true))
+	t.Cleanup(func() { response.Configure() })
+
+	if _, body := send(t, app, "/internal"); body.Get().Message != response.InternalError.Message {
+		t.Errorf("Internal message must be hidden in production. Got %+v", body)
+	}
+}
+
+func send(t *testing.T, app *fiber.App, path string) (int, response.ResponseError) {
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body response.ResponseError
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, body
+}
+
+func TestErrorHandlerSpanStatus(t *testing.T) {
+	recorder := tracingtest.Install(t)
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler()})
+	app.Use(tracing.Middleware(), Recover())
+	app.Get("/bad", func(c *fiber.Ctx) error {
+		span := trace.SpanFromContext(c.UserContext())
+		return response.NewResponseError(span, response.Error{HttpStatus: fiber.StatusBadRequest, Code: "BAD", Message: "bad"})
+	})
+	app.Get("/internal", func(c *fiber.Ctx) error {
+		return errors.New("connection refused")
+	})
+	app.Get("/panic", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+
+	send(t, app, "/bad")
+	bad := recorder.Span(t, "GET /bad").HasStatus(codes.Unset).HasEvent("bad").HasAttribute("http.response.status_code", 400)
+	if n := exceptions(bad); n != 0 {
+		t.Errorf("4xx must not record exceptions. Got %d", n)
+	}
+
+	send(t, app, "/internal")
+	internal := recorder.Span(t, "GET /internal").HasStatus(codes.Error)
+	if n := exceptions(internal); n != 1 {
+		t.Errorf("Error must be recorded once. Got %d", n)
+	}
+
+	send(t, app, "/panic")
+	panicked := recorder.Span(t, "GET /panic").HasStatus(codes.Error)
+	if n := exceptions(panicked); n != 1 {
+		t.Errorf("Panic must be recorded once. Got %d", n)
+	}
+}
+
+func exceptions(span tracingtest.Span) int {
+	count := 0
+	for _, event := range span.ReadOnlySpan().Events() {
+		if event.Name == semconv.ExceptionEventName {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,221 @@
+package response
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/nilo"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Definition declares an error once for the catalog. Message and Translations
+// (by lowercase language, e.g. "es" or "pt-br") are templates whose {0}, {1}... are filled with
+// the constructor arguments. Key identifies the definition and defaults to Code,
+// so several messages can share a code
+type Definition struct {
+	Key          string             `json:"key"`
+	Code         ErrorCode          `json:"code"`
+	HttpStatus   int                `json:"status"`
+	Message      Message            `json:"message"`
+	Translations map[string]Message `json:"translations,omitempty"`
+}
+
+// New creates the Error filling the message template with args
+func (d Definition) New(args ...any) Error {
+	return Error{HttpStatus: d.HttpStatus, Code: d.Code, Message: fill(d.Message, args)}
+}
+
+// Translate creates the Error with the message in lang or its base
+// language, falling back to the default message
+func (d Definition) Translate(lang string, args ...any) Error {
+	e := d.New(args...)
+	if msg, ok := d.Translations[lang]; ok {
+		e.Message = fill(msg, args)
+	} else if msg, ok := d.Translations[baseLanguage(lang)]; ok {
+		e.Message = fill(msg, args)
+	}
+	return e
+}
+
+// Response creates a ResponseError with the Error and logs it.
+// ToResponse translates it to the language of the request
+func (d Definition) Response(span trace.Span, args ...any) *ResponseError {
+	re := NewResponseError(span, d.New(args...))
+	re.defined = map[int]definedError{0: {d, args}}
+	return re
+}
+
+// AddDefinition adds the Error of the definition to the ResponseError and logs it
+func (re *ResponseError) AddDefinition(span trace.Span, d Definition, args ...any) *ResponseError {
+	re.Add(span, d.New(args...))
+	if re.defined == nil {
+		re.defined = make(map[int]definedError)
+	}
+	re.defined[len(re.Errors)-1] = definedError{d, args}
+	return re
+}
+
+// Wrap creates a ResponseError caused by err (see WrapError).
+// ToResponse translates it to the language of the request
+func (d Definition) Wrap(span trace.Span, err error, args ...any) *ResponseError {
+	re := WrapError(span, err, d.New(args...))
+	re.defined = map[int]definedError{0: {d, args}}
+	return re
+}
+
+// definedError remembers the definition and arguments of an Error to translate it
+type definedError struct {
+	definition Definition
+	args       []any
+}
+
+func fill(template Message, args []any) Message {
+	for i, arg := range args {
+		template = strings.ReplaceAll(template, "{"+strconv.Itoa(i)+"}", fmt.Sprint(arg))
+	}
+	return template
+}
+
+var (
+	catalogMu sync.RWMutex
+	catalog   = make(map[string]Definition)
+)
+
+// Register adds the definition to the catalog and returns it
+// (e.g. var ErrNotFound = response.Register(response.Definition{...})).
+// It panics if the key is already registered
+func Register(d Definition) Definition {
+	if d.Key == "" {
+		d.Key = d.Code
+	}
+
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	if _, ok := catalog[d.Key]; ok {
+		panic(fmt.Sprintf("error %q already registered", d.Key))
+	}
+	catalog[d.Key] = d
+	return d
+}
+
+// Lookup returns the definition registered with key
+func Lookup(key string) nilo.Option[Definition] {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	if d, ok := catalog[key]; ok {
+		return nilo.Value(d)
+	}
+	return nilo.Nil[Definition]()
+}
+
+// Catalog returns the registered definitions sorted by code and key
+func Catalog() []Definition {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	return slices.SortedFunc(maps.Values(catalog), func(a, b Definition) int {
+		return cmp.Or(cmp.Compare(a.Code, b.Code), cmp.Compare(a.Key, b.Key))
+	})
+}
+
+// CatalogFormat identifies how the catalog is written
+type CatalogFormat string
+
+const (
+	CatalogJSON     CatalogFormat = "json"
+	CatalogMarkdown CatalogFormat = "markdown"
+)
+
+// WriteCatalog writes the registered definitions (e.g. to generate the API docs)
+func WriteCatalog(w io.Writer, format CatalogFormat) error {
+	definitions := Catalog()
+
+	switch format {
+	case CatalogJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(definitions)
+	case CatalogMarkdown:
+		return writeMarkdown(w, definitions)
+	default:
+		return fmt.Errorf("unknown catalog format %q", format)
+	}
+}
+
+func writeMarkdown(w io.Writer, definitions []Definition) error {
+	var langs []string
+	for _, d := range definitions {
+		for lang := range d.Translations {
+			if !slices.Contains(langs, lang) {
+				langs = append(langs, lang)
+			}
+		}
+	}
+	slices.Sort(langs)
+
+	var sb strings.Builder
+	sb.WriteString("| Code | Status | Message |")
+	for _, lang := range langs {
+		sb.WriteString(" Message (" + lang + ") |")
+	}
+	sb.WriteString("\n|---|---|---|" + strings.Repeat("---|", len(langs)) + "\n")
+
+	for _, d := range definitions {
+		fmt.Fprintf(&sb, "| %s | %d | %s |", d.Code, d.HttpStatus, escapeCell(d.Message))
+		for _, lang := range langs {
+			sb.WriteString(" " + escapeCell(d.Translations[lang]) + " |")
+		}
+		sb.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func escapeCell(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+// CatalogHandler serves the catalog as JSON or, with ?format=markdown, as Markdown
+func CatalogHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if CatalogFormat(c.Query("format")) == CatalogMarkdown {
+			c.Set(fiber.HeaderContentType, "text/markdown; charset=utf-8")
+			return WriteCatalog(c, CatalogMarkdown)
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return WriteCatalog(c, CatalogJSON)
+	}
+}
+
+// Errors registered by the library
+var (
+	InternalError = Register(Definition{
+		Code:       "INTERNAL_ERROR",
+		HttpStatus: fiber.StatusInternalServerError,
+		Message:    "Internal server error",
+		Translations: map[string]Message{
+			"es": "Error interno del servidor",
+			"pt": "Erro interno do servidor",
+		},
+	})
+	TimeoutError = Register(Definition{
+		Code:       "TIMEOUT",
+		HttpStatus: fiber.StatusGatewayTimeout,
+		Message:    "The request took too long",
+		Translations: map[string]Message{
+			"es": "La solicitud tardó demasiado",
+			"pt": "A solicitação demorou demais",
+		},
+	})
+)
@@ -0,0 +1,64 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var errItemNotFound = Register(Definition{
+	Code:         "ITEM_NOT_FOUND",
+	HttpStatus:   404,
+	Message:      "Item {0} not found in {1}",
+	Translations: map[string]Message{"es": "Item {0} no encontrado en {1}"},
+})
+
+func TestDefinition(t *testing.T) {
+	e := errItemNotFound.New(7, "store")
+	if e.HttpStatus != 404 || e.Code != "ITEM_NOT_FOUND" || e.Message != "Item 7 not found in store" {
+		t.Errorf("Unexpected error %+v", e)
+	}
+
+	if msg := errItemNotFound.Translate("es", 7, "store").Message; msg != "Item 7 no encontrado en store" {
+		t.Errorf("Message must be translated. Got %s", msg)
+	}
+
+	if msg := errItemNotFound.Translate("fr", 7, "store").Message; msg != e.Message {
+		t.Errorf("Message must fall back to the default. Got %s", msg)
+	}
+
+	if Lookup("ITEM_NOT_FOUND").IsNil() || Lookup("UNKNOWN").IsValue() {
+		t.Error("Lookup must find only registered keys")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Duplicated key must panic")
+		}
+	}()
+	Register(Definition{Code: "ITEM_NOT_FOUND"})
+}
+
+func TestWriteCatalog(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCatalog(&buf, CatalogJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	var definitions []Definition
+	if err := json.Unmarshal(buf.Bytes(), &definitions); err != nil {
+		t.Fatal(err)
+	}
+	if len(definitions) != len(Catalog()) || definitions[0].Code != "INTERNAL_ERROR" {
+		t.Errorf("JSON must have the sorted catalog. Got %+v", definitions)
+	}
+
+	buf.Reset()
+	if err := WriteCatalog(&buf, CatalogMarkdown); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "| ITEM_NOT_FOUND | 404 | Item {0} not found in {1} | Item {0} no encontrado en {1} |") {
+		t.Errorf("Markdown must have a row per definition. Got\n%s", buf.String())
+	}
+}
@@ -0,0 +1,117 @@
+package response
+
+import (
+	"crypto/rand"
+	"os"
+	"strings"
+)
+
+// ErrorFormat identifies how a ResponseError is rendered
+type ErrorFormat string
+
+const (
+	DefaultFormat ErrorFormat = "default"
+	ProblemFormat ErrorFormat = "problem"
+)
+
+type config struct {
+	traceDetails bool
+	format       ErrorFormat
+	problemType  string
+	production   bool
+	language     string
+	stackTrace   bool
+	cursorKey    []byte
+}
+
+type ConfigOptions func(*config)
+
+// WithTraceDetails adds the trace ID, timestamp and request path to every ResponseError body
+func WithTraceDetails() ConfigOptions {
+	return func(c *config) {
+		c.traceDetails = true
+	}
+}
+
+// WithErrorFormat sets the format of every ResponseError. Requests accepting
+// application/problem+json always get ProblemFormat
+func WithErrorFormat(format ErrorFormat) ConfigOptions {
+	return func(c *config) {
+		c.format = format
+	}
+}
+
+// WithProblemTypeBase sets the URI prefixed to the error code in the problem
+// type member (e.g. https://api.example.com/problems/). Default about:blank
+func WithProblemTypeBase(uri string) ConfigOptions {
+	return func(c *config) {
+		c.problemType = uri
+	}
+}
+
+// WithProduction hides the messages of the 5xx errors with a cause from the clients.
+// If not set, it is enabled when DEPLOYMENT_ENVIRONMENT is production or prod
+func WithProduction(production bool) ConfigOptions {
+	return func(c *config) {
+		c.production = production
+	}
+}
+
+// WithDefaultLanguage sets the language used when the request accepts
+// none of the registered ones. Default en
+func WithDefaultLanguage(lang string) ConfigOptions {
+	return func(c *config) {
+		c.language = strings.ToLower(lang)
+	}
+}
+
+// WithStackTrace captures the stack of the errors created by WrapError
+// for the logs and spans. If not set, ERROR_STACK_TRACE=true enables it
+func WithStackTrace(enabled bool) ConfigOptions {
+	return func(c *config) {
+		c.stackTrace = enabled
+	}
+}
+
+// WithCursorKey sets the key signing the pagination cursors. If not set,
+// CURSOR_SECRET_KEY is used or, without it, a random key valid until restart
+func WithCursorKey(key []byte) ConfigOptions {
+	return func(c *config) {
+		c.cursorKey = key
+	}
+}
+
+var randomCursorKey = newRandomKey()
+
+func newRandomKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
+
+var cfg = newConfig()
+
+// Configure sets how the responses are rendered for the whole app
+func Configure(options ...ConfigOptions) {
+	c := newConfig()
+	for _, opt := range options {
+		opt(&c)
+	}
+	cfg = c
+}
+
+// Production reports whether the internal error messages are hidden from the clients
+func Production() bool {
+	return cfg.production
+}
+
+func newConfig() config {
+	env := strings.ToLower(os.Getenv("DEPLOYMENT_ENVIRONMENT"))
+	return config{
+		format:     DefaultFormat,
+		production: env == "production" || env == "prod",
+		language:   "en",
+		stackTrace: strings.ToLower(os.Getenv("ERROR_STACK_TRACE")) == "true",
+		cursorKey:  []byte(os.Getenv("CURSOR_SECRET_KEY")),
+	}
+}
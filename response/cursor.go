@@ -0,0 +1,231 @@
+package response
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CursorPagination has the opaque cursors of the next and previous pages
+type CursorPagination struct {
+	PageSize int    `json:"pageSize"`
+	Next     string `json:"next,omitempty"`
+	Previous string `json:"previous,omitempty"`
+}
+
+// CursorPage represents a page of a keyset paginated response
+type CursorPage[T any] struct {
+	Pagination CursorPagination `json:"pagination"`
+	Elements   []T              `json:"elements"`
+}
+
+// CursorRequest is the size, sort order and position requested. Values
+// are the sort key values the page starts after (or before, if Backward);
+// they are empty on the first page
+type CursorRequest struct {
+	Size     int
+	Sort     []Sort
+	Values   []any
+	Backward bool
+}
+
+// cursor is the payload signed into the cursor parameter
+type cursor struct {
+	Sort     string `bson:"s"`
+	Values   []any  `bson:"v"`
+	Backward bool   `bson:"b,omitempty"`
+}
+
+const tiebreaker = "_id"
+
+var InvalidCursorError = Register(Definition{
+	Code:       "INVALID_CURSOR",
+	HttpStatus: fiber.StatusBadRequest,
+	Message:    "Invalid cursor",
+	Translations: map[string]Message{
+		"es": "Cursor inválido",
+		"pt": "Cursor inválido",
+	},
+})
+
+// ParseCursorRequest parses the size, sort and cursor query parameters
+// (e.g. ?size=20&sort=-createDate&cursor=...). The sort always ends with
+// _id to make it unique, and a cursor is only valid for the sort it was created with
+func ParseCursorRequest(c *fiber.Ctx, span trace.Span, options ...PageOptions) (CursorRequest, *ResponseError) {
+	opts := newPageOption(options...)
+
+	re := &ResponseError{}
+	request := CursorRequest{
+		Size: opts.parseSize(c, span, re),
+		Sort: opts.parseSort(c, span, re),
+	}
+
+	if !slices.ContainsFunc(request.Sort, func(s Sort) bool { return s.Field == tiebreaker }) {
+		request.Sort = append(request.Sort, Sort{Field: tiebreaker})
+	}
+
+	if value := c.Query("cursor"); value != "" {
+		cur, err := decodeCursor(value)
+		if err != nil || cur.Sort != sortKey(request.Sort) || len(cur.Values) != len(request.Sort) {
+			re.AddDefinition(span, InvalidCursorError)
+		}
+		request.Values = cur.Values
+		request.Backward = cur.Backward
+	}
+
+	if len(re.Errors) > 0 {
+		return CursorRequest{}, re
+	}
+	return request, nil
+}
+
+// Filter returns the Mongo range filter of the elements after (or before)
+// the cursor, e.g. for sort -createDate,_id:
+//
+//	{$or: [{createDate: {$lt: d}}, {createDate: d, _id: {$gt: id}}]}
+func (r CursorRequest) Filter() bson.D {
+	if len(r.Values) == 0 {
+		return bson.D{}
+	}
+
+	or := bson.A{}
+	for i, s := range r.Sort {
+		condition := bson.D{}
+		for j := range i {
+			condition = append(condition, bson.E{Key: r.Sort[j].Field, Value: r.Values[j]})
+		}
+
+		op := "$gt"
+		if s.Desc != r.Backward {
+			op = "$lt"
+		}
+		condition = append(condition, bson.E{Key: s.Field, Value: bson.D{{Key: op, Value: r.Values[i]}}})
+		or = append(or, condition)
+	}
+	return bson.D{{Key: "$or", Value: or}}
+}
+
+// FindOptions returns the sort (reversed when going backward) and the limit
+// of a Mongo find. One extra element is fetched to know if there are more pages
+func (r CursorRequest) FindOptions() *options.FindOptions {
+	sort := bson.D{}
+	for _, s := range r.Sort {
+		order := 1
+		if s.Desc != r.Backward {
+			order = -1
+		}
+		sort = append(sort, bson.E{Key: s.Field, Value: order})
+	}
+	return options.Find().SetSort(sort).SetLimit(int64(r.Size + 1))
+}
+
+// NewCursorPage creates the page from the elements found with the Filter and
+// FindOptions of the request. The cursors take the sort key values from the
+// bson fields of the first and last elements
+func NewCursorPage[T any](r CursorRequest, elements []T) (CursorPage[T], error) {
+	hasMore := len(elements) > r.Size
+	if hasMore {
+		elements = elements[:r.Size]
+	}
+	if r.Backward {
+		slices.Reverse(elements)
+	}
+
+	page := CursorPage[T]{
+		Pagination: CursorPagination{PageSize: r.Size},
+		Elements:   elements,
+	}
+	if len(elements) == 0 {
+		return page, nil
+	}
+
+	var err error
+	if hasMore || r.Backward {
+		if page.Pagination.Next, err = newCursor(r.Sort, elements[len(elements)-1], false); err != nil {
+			return page, err
+		}
+	}
+	if (hasMore && r.Backward) || (!r.Backward && len(r.Values) > 0) {
+		if page.Pagination.Previous, err = newCursor(r.Sort, elements[0], true); err != nil {
+			return page, err
+		}
+	}
+	return page, nil
+}
+
+func newCursor(sort []Sort, element any, backward bool) (string, error) {
+	raw, err := bson.Marshal(element)
+	if err != nil {
+		return "", fmt.Errorf("error creating cursor: %w", err)
+	}
+
+	values := make([]any, 0, len(sort))
+	for _, s := range sort {
+		value, err := bson.Raw(raw).LookupErr(strings.Split(s.Field, ".")...)
+		if err != nil {
+			return "", fmt.Errorf("error creating cursor: sort field %s not found", s.Field)
+		}
+		values = append(values, value)
+	}
+
+	payload, err := bson.Marshal(cursor{Sort: sortKey(sort), Values: values, Backward: backward})
+	if err != nil {
+		return "", fmt.Errorf("error creating cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(sign(payload)), nil
+}
+
+func decodeCursor(value string) (cursor, error) {
+	var cur cursor
+
+	encodedPayload, encodedSignature, ok := strings.Cut(value, ".")
+	if !ok {
+		return cur, errors.New("malformed cursor")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return cur, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return cur, err
+	}
+
+	if !hmac.Equal(signature, sign(payload)) {
+		return cur, errors.New("invalid cursor signature")
+	}
+	return cur, bson.Unmarshal(payload, &cur)
+}
+
+func sign(payload []byte) []byte {
+	key := cfg.cursorKey
+	if len(key) == 0 {
+		key = randomCursorKey
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// sortKey identifies the sort a cursor was created with (e.g. -createDate,_id)
+func sortKey(sort []Sort) string {
+	fields := make([]string, 0, len(sort))
+	for _, s := range sort {
+		if s.Desc {
+			fields = append(fields, "-"+s.Field)
+		} else {
+			fields = append(fields, s.Field)
+		}
+	}
+	return strings.Join(fields, ",")
+}
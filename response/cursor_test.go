@@ -0,0 +1,114 @@
+package response
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/internal/fibertest"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type cursorItem struct {
+	ID   int    `bson:"_id"`
+	Name string `bson:"name"`
+}
+
+func parseCursor(t *testing.T, query url.Values) (CursorRequest, *ResponseError) {
+	return fibertest.ParseQuery(t, query.Encode(), func(c *fiber.Ctx) (CursorRequest, *ResponseError) {
+		return ParseCursorRequest(c, noop.Span{}, WithSortFields("name"), WithDefaultSize(2))
+	})
+}
+
+func TestCursorPagination(t *testing.T) {
+	Configure(WithCursorKey([]byte("secret")))
+	t.Cleanup(func() { Configure() })
+
+	first, re := parseCursor(t, url.Values{"sort": {"-name"}})
+	if re != nil {
+		t.Fatalf("Unexpected error %v", re.Errors)
+	}
+	if len(first.Sort) != 2 || first.Sort[1].Field != "_id" || len(first.Filter()) != 0 {
+		t.Fatalf("First page must sort by _id too and have no filter. Got %+v", first)
+	}
+	if sort := first.FindOptions().Sort.(bson.D); sort[0].Value != -1 || *first.FindOptions().Limit != 3 {
+		t.Errorf("Unexpected find options %v", sort)
+	}
+
+	page, err := NewCursorPage(first, []cursorItem{{3, "c"}, {2, "b"}, {1, "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Elements) != 2 || page.Pagination.Next == "" || page.Pagination.Previous != "" {
+		t.Fatalf("First page must have two elements and only next cursor. Got %+v", page)
+	}
+
+	next, re := parseCursor(t, url.Values{"sort": {"-name"}, "cursor": {page.Pagination.Next}})
+	if re != nil {
+		t.Fatalf("Unexpected error %v", re.Errors)
+	}
+
+	expected := bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: "name", Value: bson.D{{Key: "$lt", Value: "b"}}}},
+		bson.D{{Key: "name", Value: "b"}, {Key: "_id", Value: bson.D{{Key: "$gt", Value: int32(2)}}}},
+	}}}
+	if got, want := bsonString(t, next.Filter()), bsonString(t, expected); got != want {
+		t.Errorf("Filter must be %s. Got %s", want, got)
+	}
+
+	page, err = NewCursorPage(next, []cursorItem{{1, "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Pagination.Next != "" || page.Pagination.Previous == "" {
+		t.Fatalf("Last page must have only previous cursor. Got %+v", page)
+	}
+
+	previous, re := parseCursor(t, url.Values{"sort": {"-name"}, "cursor": {page.Pagination.Previous}})
+	if re != nil || !previous.Backward {
+		t.Fatalf("Previous cursor must go backward. Got %+v %v", previous, re)
+	}
+	if sort := previous.FindOptions().Sort.(bson.D); sort[0].Value != 1 || sort[1].Value != -1 {
+		t.Errorf("Backward sort must be reversed. Got %v", sort)
+	}
+
+	page, err = NewCursorPage(previous, []cursorItem{{2, "b"}, {3, "c"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Elements[0].Name != "c" || page.Pagination.Next == "" || page.Pagination.Previous != "" {
+		t.Errorf("Backward page must be in sort order with only next cursor. Got %+v", page)
+	}
+}
+
+func TestInvalidCursor(t *testing.T) {
+	page, err := NewCursorPage(CursorRequest{Size: 1, Sort: []Sort{{Field: "_id"}}}, []cursorItem{{1, "a"}, {2, "b"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []url.Values{
+		{"cursor": {page.Pagination.Next + "x"}},
+		{"cursor": {"not-a-cursor"}},
+		{"cursor": {page.Pagination.Next}, "sort": {"name"}},
+	}
+
+	for _, query := range cases {
+		if _, re := parseCursor(t, query); re == nil || re.Get().Code != "INVALID_CURSOR" {
+			t.Errorf("Cursor must be invalid for %v", query)
+		}
+	}
+
+	if _, re := parseCursor(t, url.Values{"cursor": {page.Pagination.Next}}); re != nil {
+		t.Errorf("Cursor must be valid for its sort. Got %v", re.Errors)
+	}
+}
+
+func bsonString(t *testing.T, d bson.D) string {
+	data, err := bson.MarshalExtJSON(d, true, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -2,10 +2,13 @@ package response
 
 import (
 	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/log"
 	"github.com/javiorfo/go-microservice-lib/tracing"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -19,14 +22,31 @@ type Error struct {
 	Message    Message   `json:"message"`
 }
 
+// Status returns the HTTP status, 500 when it is not set
+func (e Error) Status() int {
+	if e.HttpStatus == 0 {
+		return fiber.StatusInternalServerError
+	}
+	return e.HttpStatus
+}
+
 // Stringer interface implementation
 func (e Error) String() string {
 	return fmt.Sprintf("ERROR CODE: %s. MESSAGE: %s", e.Code, e.Message)
 }
 
-// ResponseError represents an array of errors
+// ResponseError represents an array of errors. TraceID, Timestamp and Path
+// are only rendered when configured with WithTraceDetails
 type ResponseError struct {
-	Errors []Error `json:"errors"`
+	Errors     []Error    `json:"errors"`
+	TraceID    string     `json:"traceId,omitempty"`
+	Timestamp  *time.Time `json:"timestamp,omitempty"`
+	Path       string     `json:"path,omitempty"`
+	span       trace.Span
+	extensions map[string]any
+	defined    map[int]definedError
+	cause      error
+	stack      string
 }
 
 // Get the first error if exists
@@ -37,12 +57,80 @@ func (re ResponseError) Get() Error {
 	return re.Errors[0]
 }
 
-// Method for Fiber reponse (implementa inteface backend.Error)
+// Method for Fiber reponse (implementa inteface backend.Error).
+// The body is rendered in the format negotiated by Format
 func (re *ResponseError) ToResponse(c *fiber.Ctx) error {
-	status := re.Get().HttpStatus
+	status := re.Get().Status()
+	lang := Language(c)
+	re.translate(lang)
+	re.sanitize(lang)
+	if cfg.traceDetails {
+		re.addTraceDetails(c)
+	}
+
+	if Format(c) == ProblemFormat {
+		problem := re.Problem(c)
+		return c.Status(problem.Status).JSON(problem, ProblemContentType)
+	}
 	return c.Status(status).JSON(re)
 }
 
+func (re *ResponseError) translate(lang string) {
+	for i, d := range re.defined {
+		re.Errors[i] = d.definition.Translate(lang, d.args...)
+	}
+}
+
+// sanitize hides the messages of the 5xx errors caused by another error in
+// production mode. The messages from definitions are kept
+func (re *ResponseError) sanitize(lang string) {
+	if re.cause == nil || !cfg.production {
+		return
+	}
+
+	for i, e := range re.Errors {
+		if _, ok := re.defined[i]; !ok && e.Status() >= fiber.StatusInternalServerError {
+			re.Errors[i].Message = InternalError.Translate(lang).Message
+		}
+	}
+}
+
+// Replace replaces the errors with the one of the definition without logging it
+// (e.g. to hide the internal details of an error already logged)
+func (re *ResponseError) Replace(d Definition, args ...any) *ResponseError {
+	re.Errors = []Error{d.New(args...)}
+	re.defined = map[int]definedError{0: {d, args}}
+	return re
+}
+
+// addTraceDetails takes the trace ID from the span in the Fiber context or,
+// if there is none, from the span the error was created with
+func (re *ResponseError) addTraceDetails(c *fiber.Ctx) {
+	spanContext := trace.SpanContextFromContext(c.UserContext())
+	if !spanContext.IsValid() && re.span != nil {
+		spanContext = re.span.SpanContext()
+	}
+	if spanContext.IsValid() {
+		re.TraceID = spanContext.TraceID().String()
+	}
+
+	now := time.Now().UTC()
+	re.Timestamp = &now
+	re.Path = c.Path()
+}
+
+// Attributes implements tracing.AttributedError with the error codes and HTTP status
+func (re ResponseError) Attributes() []attribute.KeyValue {
+	codes := make([]string, 0, len(re.Errors))
+	for _, e := range re.Errors {
+		codes = append(codes, e.Code)
+	}
+	return []attribute.KeyValue{
+		attribute.StringSlice("error.codes", codes),
+		attribute.Int("http.response.status_code", re.Get().Status()),
+	}
+}
+
 func (re ResponseError) Error() string {
 	if len(re.Errors) == 0 {
 		return "unknown error"
@@ -50,24 +138,85 @@ func (re ResponseError) Error() string {
 	return re.Errors[0].Message
 }
 
+// Unwrap returns the error that caused the ResponseError, if any
+func (re ResponseError) Unwrap() error {
+	return re.cause
+}
+
+// Is reports whether target is a ResponseError with the same first code
+func (re ResponseError) Is(target error) bool {
+	var t *ResponseError
+	switch v := target.(type) {
+	case *ResponseError:
+		t = v
+	case ResponseError:
+		t = &v
+	default:
+		return false
+	}
+	return t.Get().Code != "" && t.Get().Code == re.Get().Code
+}
+
+// StackTrace returns the stack captured by WrapError when configured with WithStackTrace
+func (re ResponseError) StackTrace() string {
+	return re.stack
+}
+
 // Add adds an error to ResponseError and logs it
 func (rre *ResponseError) Add(span trace.Span, e Error) *ResponseError {
-	log.Error(tracing.LogError(span, e.String()))
+	logError(span, e)
 
+	if rre.span == nil {
+		rre.span = span
+	}
 	rre.Errors = append(rre.Errors, e)
 	return rre
 }
 
 // NewResponseError creates an error to ResponseError and logs it
 func NewResponseError(span trace.Span, e Error) *ResponseError {
-	log.Error(tracing.LogError(span, e.String()))
+	logError(span, e)
 
 	return &ResponseError{
 		Errors: []Error{e},
+		span:   span,
 	}
 }
 
+// WrapError creates a ResponseError caused by err. The cause (and the stack
+// with WithStackTrace) is logged and recorded on the span; in production mode
+// the client only gets a generic message for 5xx errors
+func WrapError(span trace.Span, err error, e Error) *ResponseError {
+	re := &ResponseError{
+		Errors: []Error{e},
+		span:   span,
+		cause:  err,
+	}
+
+	args := []any{"cause", err.Error()}
+	if cfg.stackTrace {
+		re.stack = string(debug.Stack())
+		args = append(args, "stack", re.stack)
+	}
+
+	span.RecordError(err, trace.WithStackTrace(cfg.stackTrace))
+	logError(span, e, args...)
+	return re
+}
+
 // InternalServerError creates a generic internal server error response
 func InternalServerError(span trace.Span, msg Message) *ResponseError {
-	return NewResponseError(span, Error{fiber.StatusInternalServerError, "INTERNAL_ERROR", msg})
+	return NewResponseError(span, Error{InternalError.HttpStatus, InternalError.Code, msg})
+}
+
+// logError logs 5xx errors at error level, which sets the span as failed,
+// and client errors at warn level, which only adds an event to the span
+func logError(span trace.Span, e Error, args ...any) {
+	level := slog.LevelError
+	if e.Status() < fiber.StatusInternalServerError {
+		level = slog.LevelWarn
+	}
+
+	args = append([]any{"code", e.Code, "status", e.Status()}, args...)
+	tracing.Logger().Log(tracing.SpanContext(span), level, e.Message, args...)
 }
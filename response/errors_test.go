@@ -0,0 +1,209 @@
+package response
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/tracing"
+	"github.com/javiorfo/go-microservice-lib/tracing/tracingtest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestToResponseWithTraceDetails(t *testing.T) {
+	tracingtest.Install(t)
+	Configure(WithTraceDetails())
+	t.Cleanup(func() { Configure() })
+
+	app := fiber.New()
+	app.Use(tracing.Middleware(), tracing.CorrelationMiddleware())
+	app.Get("/items/:id", func(c *fiber.Ctx) error {
+		span := trace.SpanFromContext(c.UserContext())
+		return NewResponseError(span, Error{fiber.StatusNotFound, "NOT_FOUND", "Item not found"}).ToResponse(c)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/items/1", nil)
+	req.Header.Set(tracing.RequestIDHeader, "req-1")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("Status code must be 404. Got %d", resp.StatusCode)
+	}
+
+	if resp.Header.Get(tracing.RequestIDHeader) != "req-1" || resp.Header.Get("traceparent") == "" {
+		t.Errorf("X-Request-Id and traceparent headers must be set. Got %v", resp.Header)
+	}
+
+	var body ResponseError
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body.TraceID == "" || body.Timestamp == nil || body.Path != "/items/1" || body.Get().Code != "NOT_FOUND" {
+		t.Errorf("Body must have trace details. Got %+v", body)
+	}
+}
+
+func TestToResponseWithProblemFormat(t *testing.T) {
+	Configure(WithProblemTypeBase("https://api.example.com/problems/"))
+	t.Cleanup(func() { Configure() })
+
+	app := fiber.New()
+	app.Post("/items", func(c *fiber.Ctx) error {
+		span := trace.SpanFromContext(c.UserContext())
+		re := NewResponseError(span, Error{fiber.StatusBadRequest, "INVALID_NAME", "Name is required"})
+		re.Add(span, Error{fiber.StatusBadRequest, "INVALID_PRICE", "Price must be positive"})
+		return re.WithExtension("retryable", false).ToResponse(c)
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/items?dry=true", nil)
+	req.Header.Set(fiber.HeaderAccept, ProblemContentType)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Header.Get(fiber.HeaderContentType) != ProblemContentType {
+		t.Errorf("Content type must be %s. Got %s", ProblemContentType, resp.Header.Get(fiber.HeaderContentType))
+	}
+
+	var body map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]any{
+		"type":      "https://api.example.com/problems/invalid_name",
+		"title":     "Bad Request",
+		"status":    float64(400),
+		"detail":    "Name is required",
+		"instance":  "/items?dry=true",
+		"code":      "INVALID_NAME",
+		"retryable": false,
+	}
+	for key, value := range expected {
+		if body[key] != value {
+			t.Errorf("Member %s must be %v. Got %v", key, value, body[key])
+		}
+	}
+
+	if errs, _ := body["errors"].([]any); len(errs) != 2 {
+		t.Errorf("Errors member must have 2 errors. Got %v", body["errors"])
+	}
+}
+
+func TestFormat(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(string(Format(c)))
+	})
+
+	format := func(accept string) string {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Header.Set(fiber.HeaderAccept, accept)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	if got := format(fiber.MIMEApplicationJSON); got != string(DefaultFormat) {
+		t.Errorf("Format must be default. Got %s", got)
+	}
+	if got := format(ProblemContentType + ", application/json"); got != string(ProblemFormat) {
+		t.Errorf("Format must be problem when accepted. Got %s", got)
+	}
+	if got := format("application/json, " + ProblemContentType + ";q=0"); got != string(DefaultFormat) {
+		t.Errorf("Format must be default when problem is refused. Got %s", got)
+	}
+	if got := format("*/*"); got != string(DefaultFormat) {
+		t.Errorf("Format must be default for wildcards. Got %s", got)
+	}
+
+	Configure(WithErrorFormat(ProblemFormat))
+	t.Cleanup(func() { Configure() })
+	if got := format(fiber.MIMEApplicationJSON); got != string(ProblemFormat) {
+		t.Errorf("Format must be the configured one. Got %s", got)
+	}
+}
+
+func TestResponseWithoutStatus(t *testing.T) {
+	recorder := tracingtest.Install(t)
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		ctx, span := otel.Tracer("test").Start(c.UserContext(), "handler")
+		defer span.End()
+		c.SetUserContext(ctx)
+		return NewResponseError(span, Error{Code: "UNKNOWN", Message: "unknown"}).ToResponse(c)
+	})
+
+	for _, accept := range []string{"", ProblemContentType} {
+		req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+		req.Header.Set(fiber.HeaderAccept, accept)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != fiber.StatusInternalServerError {
+			t.Errorf("Accept %q: status must be 500. Got %d", accept, resp.StatusCode)
+		}
+	}
+
+	recorder.Span(t, "handler").HasStatus(codes.Error)
+}
+
+func TestWrapError(t *testing.T) {
+	recorder := tracingtest.Install(t)
+	Configure(WithProduction(true), WithStackTrace(true))
+	t.Cleanup(func() { Configure() })
+
+	cause := fmt.Errorf("querying items: %w", sql.ErrConnDone)
+
+	app := fiber.New()
+	app.Use(tracing.Middleware())
+	app.Get("/", func(c *fiber.Ctx) error {
+		span := trace.SpanFromContext(c.UserContext())
+		re := WrapError(span, cause, Error{fiber.StatusInternalServerError, "DB_ERROR", cause.Error()})
+
+		if !errors.Is(re, sql.ErrConnDone) || !errors.Is(re, &ResponseError{Errors: []Error{{Code: "DB_ERROR"}}}) {
+			t.Error("ResponseError must match its cause and code")
+		}
+		if re.StackTrace() == "" {
+			t.Error("Stack must be captured")
+		}
+		return re.ToResponse(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body ResponseError
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if e := body.Get(); e.Code != "DB_ERROR" || e.Message != InternalError.Message {
+		t.Errorf("Message must be hidden in production. Got %+v", e)
+	}
+
+	recorder.Span(t, "GET /").HasEvent("exception")
+
+	logs := recorder.Logs(t)
+	if len(logs) != 1 || logs[0]["cause"] != cause.Error() || logs[0]["stack"] == nil {
+		t.Errorf("Cause and stack must be logged. Got %v", logs)
+	}
+}
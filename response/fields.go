@@ -0,0 +1,248 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Fields are the JSON paths selected with the fields query parameter
+// (e.g. ?fields=name,address.city). Without paths nothing is projected
+type Fields struct {
+	Paths     []string
+	bsonPaths []string
+}
+
+var FieldsError = Register(Definition{
+	Code:       "INVALID_FIELDS",
+	HttpStatus: fiber.StatusBadRequest,
+	Message:    "Field {0} does not exist",
+	Translations: map[string]Message{
+		"es": "El campo {0} no existe",
+		"pt": "O campo {0} não existe",
+	},
+})
+
+// ParseFields parses the fields query parameter, rejecting the paths
+// that are not JSON fields of T (the element type for paginated responses)
+func ParseFields[T any](c *fiber.Ctx, span trace.Span) (Fields, *ResponseError) {
+	known := make(map[string]string)
+	collectPaths(reflect.TypeFor[T](), "", "", known, nil)
+
+	re := &ResponseError{}
+	var fields Fields
+
+	for path := range strings.SplitSeq(c.Query("fields"), ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+
+		bsonPath, ok := known[path]
+		if !ok {
+			re.AddDefinition(span, FieldsError, path)
+			continue
+		}
+		fields.Paths = append(fields.Paths, path)
+		fields.bsonPaths = append(fields.bsonPaths, bsonPath)
+	}
+
+	if len(re.Errors) > 0 {
+		return Fields{}, re
+	}
+	return fields.collapse(), nil
+}
+
+// collapse removes the duplicated paths and the children of selected
+// parents, which MongoDB rejects as a path collision
+func (f Fields) collapse() Fields {
+	var collapsed Fields
+	for i, path := range f.Paths {
+		covered := slices.ContainsFunc(f.Paths, func(other string) bool {
+			return strings.HasPrefix(path, other+".")
+		}) || slices.Contains(f.Paths[:i], path)
+
+		if !covered {
+			collapsed.Paths = append(collapsed.Paths, path)
+			collapsed.bsonPaths = append(collapsed.bsonPaths, f.bsonPaths[i])
+		}
+	}
+	return collapsed
+}
+
+// Projection returns the Mongo projection of the fields, or nil without fields
+// (e.g. options.Find().SetProjection(fields.Projection()))
+func (f Fields) Projection() bson.D {
+	if len(f.bsonPaths) == 0 {
+		return nil
+	}
+
+	projection := bson.D{}
+	for _, path := range f.bsonPaths {
+		projection = append(projection, bson.E{Key: path, Value: 1})
+	}
+	return projection
+}
+
+// Apply projects value to the fields. The elements of PaginationResponse
+// and CursorPage, and the items of slices, are projected one by one
+func (f Fields) Apply(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// numbers are kept as json.Number so integers above 2^53 are not rounded
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var node any
+	if err := decoder.Decode(&node); err != nil {
+		return nil, err
+	}
+
+	if len(f.Paths) == 0 {
+		return node, nil
+	}
+
+	tree := pathTree(f.Paths)
+	if _, ok := value.(paged); ok {
+		page := node.(map[string]any)
+		page["elements"] = project(page["elements"], tree)
+		return page, nil
+	}
+	return project(node, tree), nil
+}
+
+// Send responds with value projected to the fields
+func (f Fields) Send(c *fiber.Ctx, value any) error {
+	if len(f.Paths) == 0 {
+		return c.JSON(value)
+	}
+
+	projected, err := f.Apply(value)
+	if err != nil {
+		return err
+	}
+	return c.JSON(projected)
+}
+
+// paged is implemented by the responses whose elements are projected
+type paged interface {
+	paged()
+}
+
+func (PaginationResponse[T]) paged() {}
+func (CursorPage[T]) paged()         {}
+
+// fieldTree has the selected children of a path; nil selects the whole value
+type fieldTree map[string]fieldTree
+
+func pathTree(paths []string) fieldTree {
+	tree := fieldTree{}
+	for _, path := range paths {
+		node := tree
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			child, exists := node[part]
+			if exists && child == nil {
+				break
+			}
+			if i == len(parts)-1 {
+				node[part] = nil
+				break
+			}
+			if !exists {
+				child = fieldTree{}
+				node[part] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+func project(node any, tree fieldTree) any {
+	switch v := node.(type) {
+	case []any:
+		for i, item := range v {
+			v[i] = project(item, tree)
+		}
+		return v
+	case map[string]any:
+		projected := make(map[string]any, len(tree))
+		for key, child := range tree {
+			value, ok := v[key]
+			if !ok {
+				continue
+			}
+			if child == nil {
+				projected[key] = value
+			} else {
+				projected[key] = project(value, child)
+			}
+		}
+		return projected
+	default:
+		return node
+	}
+}
+
+var jsonMarshaler = reflect.TypeFor[json.Marshaler]()
+
+// collectPaths maps the JSON paths of t to their bson paths
+func collectPaths(t reflect.Type, jsonPrefix, bsonPrefix string, paths map[string]string, visiting []reflect.Type) {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t.Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(jsonMarshaler) {
+		return
+	}
+	for _, v := range visiting {
+		if v == t {
+			return
+		}
+	}
+	visiting = append(visiting, t)
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if jsonName == "-" || (!field.IsExported() && (!field.Anonymous || jsonName != "")) {
+			continue
+		}
+		bsonName, bsonOptions, _ := strings.Cut(field.Tag.Get("bson"), ",")
+		if bsonName == "" {
+			bsonName = strings.ToLower(field.Name)
+		}
+		bsonPath := bsonPrefix + bsonName
+		if strings.Contains(bsonOptions, "inline") {
+			bsonPath = strings.TrimSuffix(bsonPrefix, ".")
+		}
+
+		if field.Anonymous && jsonName == "" {
+			collectPaths(field.Type, jsonPrefix, prefix(bsonPath), paths, visiting)
+			continue
+		}
+
+		if jsonName == "" {
+			jsonName = field.Name
+		}
+		jsonPath := jsonPrefix + jsonName
+		paths[jsonPath] = bsonPath
+		collectPaths(field.Type, jsonPath+".", bsonPath+".", paths, visiting)
+	}
+}
+
+func prefix(path string) string {
+	if path == "" {
+		return ""
+	}
+	return path + "."
+}
@@ -0,0 +1,169 @@
+package response
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/internal/fibertest"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type address struct {
+	City   string `json:"city" bson:"city"`
+	Street string `json:"street" bson:"street_name"`
+}
+
+type audit struct {
+	CreateDate time.Time `json:"createDate" bson:"create_date"`
+}
+
+type person struct {
+	audit   `bson:",inline"`
+	ID      string    `json:"id" bson:"_id"`
+	Name    string    `json:"name" bson:"name"`
+	Secret  string    `json:"-"`
+	Address address   `json:"address" bson:"address"`
+	Phones  []address `json:"phones" bson:"phones"`
+}
+
+func parseFields(t *testing.T, query string) (Fields, *ResponseError) {
+	return fibertest.ParseQuery(t, query, func(c *fiber.Ctx) (Fields, *ResponseError) {
+		return ParseFields[person](c, noop.Span{})
+	})
+}
+
+func TestParseFields(t *testing.T) {
+	fields, re := parseFields(t, "fields=name,%20address.city,createDate,phones.street")
+	if re != nil {
+		t.Fatalf("Unexpected error %v", re.Errors)
+	}
+
+	expected := bson.D{{Key: "name", Value: 1}, {Key: "address.city", Value: 1}, {Key: "create_date", Value: 1}, {Key: "phones.street_name", Value: 1}}
+	if got := fields.Projection(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Projection must be %v. Got %v", expected, got)
+	}
+}
+
+func TestParseFieldsUnknown(t *testing.T) {
+	_, re := parseFields(t, "fields=name,Secret,address.zip")
+	if re == nil || len(re.Errors) != 2 {
+		t.Fatalf("Expected 2 errors. Got %v", re)
+	}
+	if re.Errors[0].Code != "INVALID_FIELDS" || re.Errors[0].Message != "Field Secret does not exist" {
+		t.Errorf("Unexpected error %+v", re.Errors[0])
+	}
+}
+
+func TestParseFieldsOverlapping(t *testing.T) {
+	fields, re := parseFields(t, "fields=address.city,name,address,name,phones.city")
+	if re != nil {
+		t.Fatalf("Unexpected error %v", re.Errors)
+	}
+
+	expected := bson.D{{Key: "name", Value: 1}, {Key: "address", Value: 1}, {Key: "phones.city", Value: 1}}
+	if got := fields.Projection(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Projection must be %v. Got %v", expected, got)
+	}
+	if !slices.Equal(fields.Paths, []string{"name", "address", "phones.city"}) {
+		t.Errorf("Unexpected paths %v", fields.Paths)
+	}
+}
+
+func TestFieldsApplyLargeNumbers(t *testing.T) {
+	value := map[string]any{"id": int64(9007199254740993), "amount": 12.5, "name": "John"}
+
+	projected, err := Fields{Paths: []string{"id", "amount"}}.Apply(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(projected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"amount":12.5,"id":9007199254740993}`; string(data) != expected {
+		t.Errorf("Projection must be %s. Got %s", expected, data)
+	}
+}
+
+func TestParseFieldsEmpty(t *testing.T) {
+	fields, re := parseFields(t, "")
+	if re != nil {
+		t.Fatalf("Unexpected error %v", re.Errors)
+	}
+	if fields.Projection() != nil {
+		t.Errorf("Projection must be nil. Got %v", fields.Projection())
+	}
+}
+
+func TestFieldsApply(t *testing.T) {
+	fields := Fields{Paths: []string{"name", "address.city", "phones.city"}}
+	p := person{
+		ID:      "1",
+		Name:    "John",
+		Address: address{City: "Rome", Street: "Via Roma"},
+		Phones:  []address{{City: "Paris", Street: "Rue"}},
+	}
+
+	projected, err := fields.Apply(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]any{
+		"name":    "John",
+		"address": map[string]any{"city": "Rome"},
+		"phones":  []any{map[string]any{"city": "Paris"}},
+	}
+	if !reflect.DeepEqual(projected, expected) {
+		t.Errorf("Projection must be %v. Got %v", expected, projected)
+	}
+}
+
+func TestPaginationWithFields(t *testing.T) {
+	app := fiber.New()
+	app.Get("/people", func(c *fiber.Ctx) error {
+		fields, re := ParseFields[person](c, noop.Span{})
+		if re != nil {
+			return re.ToResponse(c)
+		}
+		response := NewPageResponse(PageRequest{Page: 1, Size: 10}, 1, []person{{ID: "1", Name: "John"}})
+		return response.ToResponse(c, WithFields(fields))
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/people?fields=name", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body struct {
+		Pagination Pagination       `json:"pagination"`
+		Elements   []map[string]any `json:"elements"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body.Pagination.Total != 1 || body.Pagination.Links == nil {
+		t.Errorf("Pagination must not be projected. Got %+v", body.Pagination)
+	}
+	if expected := []map[string]any{{"name": "John"}}; !reflect.DeepEqual(body.Elements, expected) {
+		t.Errorf("Elements must be %v. Got %v", expected, body.Elements)
+	}
+
+	resp, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/people?fields=age", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		data, _ := io.ReadAll(resp.Body)
+		t.Errorf("Status must be 400. Got %d %s", resp.StatusCode, data)
+	}
+}
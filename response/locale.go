@@ -0,0 +1,90 @@
+package response
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	languagesMu sync.RWMutex
+	languages   = []string{"en", "es", "pt"}
+)
+
+// RegisterLanguage adds languages (e.g. "fr" or "pt-BR") to the ones negotiated by Language
+func RegisterLanguage(langs ...string) {
+	languagesMu.Lock()
+	defer languagesMu.Unlock()
+
+	for _, lang := range langs {
+		lang = strings.ToLower(lang)
+		if !slices.Contains(languages, lang) {
+			languages = append(languages, lang)
+		}
+	}
+}
+
+// Language negotiates the language of the request from Accept-Language.
+// Each accepted tag (by quality) matches a registered language exactly or
+// by its base (pt-BR matches pt). Without match the default language is used
+func Language(c *fiber.Ctx) string {
+	languagesMu.RLock()
+	defer languagesMu.RUnlock()
+
+	return negotiate(c.Get(fiber.HeaderAcceptLanguage), languages, cfg.language)
+}
+
+func negotiate(header string, supported []string, fallback string) string {
+	type accepted struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []accepted
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := acceptQuality(params)
+		if tag != "" && quality > 0 {
+			tags = append(tags, accepted{strings.ToLower(tag), quality})
+		}
+	}
+
+	slices.SortStableFunc(tags, func(a, b accepted) int {
+		return cmp.Compare(b.quality, a.quality)
+	})
+
+	for _, t := range tags {
+		if t.tag == "*" {
+			return fallback
+		}
+		if slices.Contains(supported, t.tag) {
+			return t.tag
+		}
+		if base, _, ok := strings.Cut(t.tag, "-"); ok && slices.Contains(supported, base) {
+			return base
+		}
+	}
+	return fallback
+}
+
+// acceptQuality returns the q parameter of an Accept* header element, 1 if not set
+func acceptQuality(params string) float64 {
+	for param := range strings.SplitSeq(params, ";") {
+		if q, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+			if v, err := strconv.ParseFloat(q, 64); err == nil {
+				return v
+			}
+		}
+	}
+	return 1
+}
+
+// baseLanguage returns the language without region (e.g. pt for pt-BR)
+func baseLanguage(lang string) string {
+	base, _, _ := strings.Cut(lang, "-")
+	return base
+}
@@ -0,0 +1,56 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{"en", "es", "pt", "pt-br"}
+
+	cases := map[string]string{
+		"":                         "en",
+		"es":                       "es",
+		"es-AR,en;q=0.8":           "es",
+		"fr;q=0.9,pt-BR":           "pt-br",
+		"pt-PT":                    "pt",
+		"fr,de;q=0.5":              "en",
+		"en;q=0.5,es;q=0.9":        "es",
+		"es;q=0,pt;q=0.1":          "pt",
+		"*":                        "en",
+		"de-CH, de;q=0.9, es;q=.7": "es",
+	}
+
+	for header, expected := range cases {
+		if got := negotiate(header, supported, "en"); got != expected {
+			t.Errorf("Language for %q must be %s. Got %s", header, expected, got)
+		}
+	}
+}
+
+func TestToResponseTranslated(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		return TimeoutError.Response(noop.Span{}).ToResponse(c)
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "pt-BR,pt;q=0.9")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body ResponseError
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	if msg := body.Get().Message; msg != TimeoutError.Translations["pt"] {
+		t.Errorf("Message must be in Portuguese. Got %s", msg)
+	}
+}
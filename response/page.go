@@ -0,0 +1,245 @@
+package response
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ColumnMapper maps a Go field name to its SQL column (e.g. auditory.Auditable)
+type ColumnMapper interface {
+	MapFieldToSQLColumn(fieldName string) string
+}
+
+// SQLColumn maps a query field (e.g. createDate) to its column through the Go
+// field name the mapper is keyed on (CreateDate). When the mapper has no column
+// for the Go name, the query field is mapped as is. Without mapper the field is the column
+func SQLColumn(mapper ColumnMapper, field string) string {
+	if mapper == nil || field == "" {
+		return field
+	}
+
+	goName := strings.ToUpper(field[:1]) + field[1:]
+	if column := mapper.MapFieldToSQLColumn(goName); column != "" && column != goName {
+		return column
+	}
+	return mapper.MapFieldToSQLColumn(field)
+}
+
+// Sort is a field of the sort order
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// PageRequest is the page (starting at 1), size and sort order requested
+type PageRequest struct {
+	Page int
+	Size int
+	Sort []Sort
+}
+
+const defaultPageSize = 10
+
+type pageOption struct {
+	defaultSize int
+	maxSize     int
+	sortFields  []string
+	defaultSort string
+}
+
+type PageOptions func(*pageOption)
+
+// WithDefaultSize sets the size used when the request has none. Default 10
+func WithDefaultSize(size int) PageOptions {
+	return func(o *pageOption) {
+		o.defaultSize = size
+	}
+}
+
+// WithMaxSize sets the maximum size accepted. Default 100
+func WithMaxSize(size int) PageOptions {
+	return func(o *pageOption) {
+		o.maxSize = size
+	}
+}
+
+// WithSortFields sets the fields allowed in the sort parameter. Without them sorting is rejected
+func WithSortFields(fields ...string) PageOptions {
+	return func(o *pageOption) {
+		o.sortFields = append(o.sortFields, fields...)
+	}
+}
+
+// WithDefaultSort sets the sort used when the request has none (e.g. "-createDate")
+func WithDefaultSort(sort string) PageOptions {
+	return func(o *pageOption) {
+		o.defaultSort = sort
+	}
+}
+
+// Pagination errors
+var (
+	InvalidPageError = Register(Definition{
+		Key:        "INVALID_PAGINATION.number",
+		Code:       "INVALID_PAGINATION",
+		HttpStatus: fiber.StatusBadRequest,
+		Message:    "Parameter {0} must be a positive integer",
+		Translations: map[string]Message{
+			"es": "El parámetro {0} debe ser un entero positivo",
+			"pt": "O parâmetro {0} deve ser um inteiro positivo",
+		},
+	})
+	PageSizeError = Register(Definition{
+		Key:        "INVALID_PAGINATION.size",
+		Code:       "INVALID_PAGINATION",
+		HttpStatus: fiber.StatusBadRequest,
+		Message:    "Parameter size must not exceed {0}",
+		Translations: map[string]Message{
+			"es": "El parámetro size no debe superar {0}",
+			"pt": "O parâmetro size não deve exceder {0}",
+		},
+	})
+	SortFieldError = Register(Definition{
+		Key:        "INVALID_PAGINATION.sort",
+		Code:       "INVALID_PAGINATION",
+		HttpStatus: fiber.StatusBadRequest,
+		Message:    "Sort field {0} is not allowed",
+		Translations: map[string]Message{
+			"es": "El campo de orden {0} no está permitido",
+			"pt": "O campo de ordenação {0} não é permitido",
+		},
+	})
+)
+
+// ParsePageRequest parses the page, size and sort query parameters
+// (e.g. ?page=2&size=20&sort=name,-createDate). A leading - sorts descending
+func ParsePageRequest(c *fiber.Ctx, span trace.Span, options ...PageOptions) (PageRequest, *ResponseError) {
+	opts := newPageOption(options...)
+
+	re := &ResponseError{}
+	page := PageRequest{Page: 1}
+
+	if value := c.Query("page"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			re.AddDefinition(span, InvalidPageError, "page")
+		}
+		page.Page = n
+	}
+
+	page.Size = opts.parseSize(c, span, re)
+
+	// pages whose offset overflows are as invalid as non positive ones
+	if page.Page > 1 && page.Size > 0 && page.Page-1 > math.MaxInt/page.Size {
+		re.AddDefinition(span, InvalidPageError, "page")
+	}
+
+	page.Sort = opts.parseSort(c, span, re)
+
+	if len(re.Errors) > 0 {
+		return PageRequest{}, re
+	}
+	return page, nil
+}
+
+func newPageOption(options ...PageOptions) pageOption {
+	opts := pageOption{defaultSize: defaultPageSize, maxSize: 100}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
+
+func (o pageOption) parseSize(c *fiber.Ctx, span trace.Span, re *ResponseError) int {
+	value := c.Query("size")
+	if value == "" {
+		return o.defaultSize
+	}
+
+	n, err := strconv.Atoi(value)
+	switch {
+	case err != nil || n < 1:
+		re.AddDefinition(span, InvalidPageError, "size")
+	case n > o.maxSize:
+		re.AddDefinition(span, PageSizeError, o.maxSize)
+	}
+	return n
+}
+
+func (o pageOption) parseSort(c *fiber.Ctx, span trace.Span, re *ResponseError) []Sort {
+	var sorts []Sort
+	for field := range strings.SplitSeq(c.Query("sort", o.defaultSort), ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		name, desc := strings.CutPrefix(field, "-")
+		name = strings.TrimPrefix(name, "+")
+		if !slices.Contains(o.sortFields, name) {
+			re.AddDefinition(span, SortFieldError, name)
+			continue
+		}
+		sorts = append(sorts, Sort{Field: name, Desc: desc})
+	}
+	return sorts
+}
+
+// Offset returns the number of elements skipped
+func (p PageRequest) Offset() int {
+	return (p.Page - 1) * p.Size
+}
+
+// FindOptions returns the skip, limit and sort of a Mongo find
+func (p PageRequest) FindOptions() *options.FindOptions {
+	opts := options.Find().SetSkip(int64(p.Offset())).SetLimit(int64(p.Size))
+	if len(p.Sort) > 0 {
+		sort := bson.D{}
+		for _, s := range p.Sort {
+			order := 1
+			if s.Desc {
+				order = -1
+			}
+			sort = append(sort, bson.E{Key: s.Field, Value: order})
+		}
+		opts.SetSort(sort)
+	}
+	return opts
+}
+
+// OrderBy returns the SQL ORDER BY clause, or an empty string without sort.
+// The fields are mapped to columns with SQLColumn
+func (p PageRequest) OrderBy(mapper ColumnMapper) string {
+	if len(p.Sort) == 0 {
+		return ""
+	}
+
+	columns := make([]string, 0, len(p.Sort))
+	for _, s := range p.Sort {
+		column := SQLColumn(mapper, s.Field)
+		if s.Desc {
+			columns = append(columns, column+" DESC")
+		} else {
+			columns = append(columns, column+" ASC")
+		}
+	}
+	return "ORDER BY " + strings.Join(columns, ", ")
+}
+
+// SQL returns the ORDER BY, LIMIT and OFFSET clauses
+// (e.g. ORDER BY name ASC LIMIT 10 OFFSET 20)
+func (p PageRequest) SQL(mapper ColumnMapper) string {
+	limit := fmt.Sprintf("LIMIT %d OFFSET %d", p.Size, p.Offset())
+	if orderBy := p.OrderBy(mapper); orderBy != "" {
+		return orderBy + " " + limit
+	}
+	return limit
+}
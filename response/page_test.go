@@ -0,0 +1,93 @@
+package response
+
+import (
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/auditory"
+	"github.com/javiorfo/go-microservice-lib/internal/fibertest"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type columns map[string]string
+
+func (c columns) MapFieldToSQLColumn(field string) string {
+	return c[field]
+}
+
+func parsePage(t *testing.T, query string, options ...PageOptions) (PageRequest, *ResponseError) {
+	return fibertest.ParseQuery(t, query, func(c *fiber.Ctx) (PageRequest, *ResponseError) {
+		return ParsePageRequest(c, noop.Span{}, options...)
+	})
+}
+
+func TestParsePageRequest(t *testing.T) {
+	page, re := parsePage(t, "page=3&size=20&sort=name,-createDate", WithSortFields("name", "createDate"))
+	if re != nil {
+		t.Fatalf("Unexpected error %v", re.Errors)
+	}
+
+	if page.Page != 3 || page.Size != 20 || len(page.Sort) != 2 || page.Sort[1] != (Sort{"createDate", true}) {
+		t.Errorf("Unexpected page %+v", page)
+	}
+
+	if sql := page.SQL(columns{"name": "name", "createDate": "create_date"}); sql != "ORDER BY name ASC, create_date DESC LIMIT 20 OFFSET 40" {
+		t.Errorf("Unexpected SQL %s", sql)
+	}
+
+	opts := page.FindOptions()
+	if *opts.Skip != 40 || *opts.Limit != 20 {
+		t.Errorf("Unexpected skip and limit %d %d", *opts.Skip, *opts.Limit)
+	}
+	if sort := opts.Sort.(bson.D); sort[0].Key != "name" || sort[1].Value != -1 {
+		t.Errorf("Unexpected sort %v", sort)
+	}
+}
+
+func TestOrderByAuditable(t *testing.T) {
+	page := PageRequest{Page: 1, Size: 10, Sort: []Sort{{Field: "createDate", Desc: true}, {Field: "name"}}}
+	if orderBy := page.OrderBy(auditory.Auditable{}); orderBy != "ORDER BY create_date DESC, name ASC" {
+		t.Errorf("Unexpected ORDER BY %s", orderBy)
+	}
+}
+
+func TestParsePageRequestDefaults(t *testing.T) {
+	page, re := parsePage(t, "", WithDefaultSize(25), WithSortFields("createDate"), WithDefaultSort("-createDate"))
+	if re != nil {
+		t.Fatalf("Unexpected error %v", re.Errors)
+	}
+
+	if page.Page != 1 || page.Size != 25 || page.Sort[0] != (Sort{"createDate", true}) {
+		t.Errorf("Unexpected page %+v", page)
+	}
+
+	if sql := page.SQL(nil); sql != "ORDER BY createDate DESC LIMIT 25 OFFSET 0" {
+		t.Errorf("Unexpected SQL %s", sql)
+	}
+}
+
+func TestParsePageRequestErrors(t *testing.T) {
+	_, re := parsePage(t, "page=0&size=500&sort=password", WithSortFields("name"))
+	if re == nil {
+		t.Fatal("Invalid parameters must fail")
+	}
+
+	expected := []string{
+		"Parameter page must be a positive integer",
+		"Parameter size must not exceed 100",
+		"Sort field password is not allowed",
+	}
+	if len(re.Errors) != len(expected) {
+		t.Fatalf("Errors must be %v. Got %v", expected, re.Errors)
+	}
+	for i, e := range re.Errors {
+		if e.Code != "INVALID_PAGINATION" || e.HttpStatus != fiber.StatusBadRequest || e.Message != expected[i] {
+			t.Errorf("Unexpected error %+v", e)
+		}
+	}
+	_, re = parsePage(t, "page=9223372036854775807&size=20")
+	if re == nil || len(re.Errors) != 1 || re.Errors[0].Message != "Parameter page must be a positive integer" {
+		t.Errorf("Page overflowing the offset must fail. Got %v", re)
+	}
+}
@@ -1,12 +1,31 @@
 package response
 
-import "strconv"
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 // PaginationResponse represents pagination details
 type Pagination struct {
-	PageNumber int   `json:"pageNumber"`
-	PageSize   int   `json:"pageSize"`
-	Total      int64 `json:"total"`
+	PageNumber  int    `json:"pageNumber"`
+	PageSize    int    `json:"pageSize"`
+	Total       int64  `json:"total"`
+	TotalPages  int    `json:"totalPages"`
+	HasNext     bool   `json:"hasNext"`
+	HasPrevious bool   `json:"hasPrevious"`
+	Links       *Links `json:"links,omitempty"`
+}
+
+// Links are the URLs of the first, previous, next and last pages
+type Links struct {
+	First    string `json:"first"`
+	Previous string `json:"prev,omitempty"`
+	Next     string `json:"next,omitempty"`
+	Last     string `json:"last"`
 }
 
 // RestResponsePagination represents a paginated response
@@ -15,13 +34,122 @@ type PaginationResponse[T any] struct {
 	Elements   []T        `json:"elements"`
 }
 
+// NewPaginationResponse creates the response from the raw page and size parameters,
+// which are kept as they are. The total pages are only computed for a positive size
 func NewPaginationResponse[T any](pageNumber, pageSize string, total int64, Elements []T) PaginationResponse[T] {
 	pn, _ := strconv.Atoi(pageNumber)
 	ps, _ := strconv.Atoi(pageSize)
+	return PaginationResponse[T]{newPagination(pn, ps, total), Elements}
+}
+
+// NewPageResponse creates the response of the requested page. Pages start at 1:
+// a page below 1 is 1 and a size below 1 is the default 10
+func NewPageResponse[T any](page PageRequest, total int64, elements []T) PaginationResponse[T] {
+	pageSize := page.Size
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	return PaginationResponse[T]{newPagination(max(page.Page, 1), pageSize, total), elements}
+}
+
+// newPagination computes the total pages and navigation of pages starting at 1
+func newPagination(pageNumber, pageSize int, total int64) Pagination {
 	p := Pagination{
-		PageNumber: pn,
-		PageSize:   ps,
+		PageNumber: pageNumber,
+		PageSize:   pageSize,
 		Total:      total,
 	}
-	return PaginationResponse[T]{p, Elements}
+
+	if pageSize > 0 {
+		p.TotalPages = int((total + int64(pageSize) - 1) / int64(pageSize))
+	}
+	p.HasNext = pageNumber < p.TotalPages
+	p.HasPrevious = pageNumber > 1
+	return p
+}
+
+type linkOption struct {
+	headers bool
+	fields  Fields
+}
+
+type LinkOptions func(*linkOption)
+
+// WithLinkHeaders also sends the links as an RFC 8288 Link header and the total as X-Total-Count
+func WithLinkHeaders() LinkOptions {
+	return func(o *linkOption) {
+		o.headers = true
+	}
+}
+
+// WithFields projects the elements to the fields parsed with ParseFields
+func WithFields(fields Fields) LinkOptions {
+	return func(o *linkOption) {
+		o.fields = fields
+	}
+}
+
+// AddLinks sets the URLs of the pages from the request URL, keeping its query
+// parameters and replacing page and size. Without a positive size there are no links
+func (r *PaginationResponse[T]) AddLinks(c *fiber.Ctx) {
+	p := &r.Pagination
+	if p.PageSize < 1 {
+		return
+	}
+	lastPage := max(p.TotalPages, 1)
+
+	p.Links = &Links{
+		First: pageURL(c, 1, p.PageSize),
+		Last:  pageURL(c, lastPage, p.PageSize),
+	}
+	if p.HasPrevious {
+		p.Links.Previous = pageURL(c, min(p.PageNumber-1, lastPage), p.PageSize)
+	}
+	if p.HasNext {
+		p.Links.Next = pageURL(c, p.PageNumber+1, p.PageSize)
+	}
+}
+
+// ToResponse adds the links and sends the response
+func (r *PaginationResponse[T]) ToResponse(c *fiber.Ctx, options ...LinkOptions) error {
+	opts := linkOption{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	r.AddLinks(c)
+
+	if opts.headers {
+		if links := r.Pagination.Links; links != nil {
+			header := []string{linkHeader(links.First, "first")}
+			if links.Previous != "" {
+				header = append(header, linkHeader(links.Previous, "prev"))
+			}
+			if links.Next != "" {
+				header = append(header, linkHeader(links.Next, "next"))
+			}
+			header = append(header, linkHeader(links.Last, "last"))
+			c.Set(fiber.HeaderLink, strings.Join(header, ", "))
+		}
+		c.Set("X-Total-Count", strconv.FormatInt(r.Pagination.Total, 10))
+	}
+	return opts.fields.Send(c, r)
+}
+
+func pageURL(c *fiber.Ctx, page, size int) string {
+	u, err := url.Parse(c.OriginalURL())
+	if err != nil {
+		u = &url.URL{Path: c.Path()}
+	}
+
+	query := u.Query()
+	query.Set("page", strconv.Itoa(page))
+	query.Set("size", strconv.Itoa(size))
+	u.RawQuery = query.Encode()
+
+	return c.BaseURL() + u.RequestURI()
+}
+
+func linkHeader(url, rel string) string {
+	return fmt.Sprintf(`<%s>; rel="%s"`, url, rel)
 }
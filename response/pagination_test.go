@@ -0,0 +1,126 @@
+package response
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestPaginationLinks(t *testing.T) {
+	app := fiber.New()
+	app.Get("/items", func(c *fiber.Ctx) error {
+		response := NewPaginationResponse(c.Query("page"), c.Query("size"), 45, []string{"a", "b"})
+		return response.ToResponse(c, WithLinkHeaders())
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "http://api.test/items?status=OPEN&page=2&size=10", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body PaginationResponse[string]
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	p := body.Pagination
+	if p.TotalPages != 5 || !p.HasNext || !p.HasPrevious {
+		t.Errorf("Unexpected pagination %+v", p)
+	}
+
+	expected := Links{
+		First:    "http://api.test/items?page=1&size=10&status=OPEN",
+		Previous: "http://api.test/items?page=1&size=10&status=OPEN",
+		Next:     "http://api.test/items?page=3&size=10&status=OPEN",
+		Last:     "http://api.test/items?page=5&size=10&status=OPEN",
+	}
+	if *p.Links != expected {
+		t.Errorf("Links must be %+v. Got %+v", expected, *p.Links)
+	}
+
+	link := `<http://api.test/items?page=1&size=10&status=OPEN>; rel="first", ` +
+		`<http://api.test/items?page=1&size=10&status=OPEN>; rel="prev", ` +
+		`<http://api.test/items?page=3&size=10&status=OPEN>; rel="next", ` +
+		`<http://api.test/items?page=5&size=10&status=OPEN>; rel="last"`
+	if got := resp.Header.Get(fiber.HeaderLink); got != link {
+		t.Errorf("Link header must be %s. Got %s", link, got)
+	}
+	if got := resp.Header.Get("X-Total-Count"); got != "45" {
+		t.Errorf("X-Total-Count must be 45. Got %s", got)
+	}
+}
+
+func TestPaginationLastPage(t *testing.T) {
+	p := NewPageResponse(PageRequest{Page: 3, Size: 10}, 30, []int{}).Pagination
+	if p.TotalPages != 3 || p.HasNext || !p.HasPrevious {
+		t.Errorf("Unexpected pagination %+v", p)
+	}
+
+	p = NewPageResponse(PageRequest{Page: 1, Size: 10}, 0, []int{}).Pagination
+	if p.TotalPages != 0 || p.HasNext || p.HasPrevious {
+		t.Errorf("Unexpected empty pagination %+v", p)
+	}
+}
+
+func TestPaginationInvalidSize(t *testing.T) {
+	app := fiber.New()
+	app.Get("/items", func(c *fiber.Ctx) error {
+		response := NewPaginationResponse(c.Query("page"), c.Query("size"), 25, []string{"a"})
+		return response.ToResponse(c, WithLinkHeaders())
+	})
+
+	for _, query := range []string{"size=0", "page=0&size=-5", ""} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "http://api.test/items?"+query, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var body PaginationResponse[string]
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+
+		p := body.Pagination
+		if p.PageSize > 0 || p.TotalPages != 0 || p.HasNext || p.Links != nil {
+			t.Errorf("%s: unexpected pagination %+v", query, p)
+		}
+		if resp.Header.Get(fiber.HeaderLink) != "" || resp.Header.Get("X-Total-Count") != "25" {
+			t.Errorf("%s: unexpected headers %v", query, resp.Header)
+		}
+	}
+}
+
+func TestPageResponseDefaults(t *testing.T) {
+	p := NewPageResponse(PageRequest{Page: 0, Size: -5}, 25, []string{"a"}).Pagination
+	if p.PageNumber != 1 || p.PageSize != 10 || p.TotalPages != 3 || !p.HasNext || p.HasPrevious {
+		t.Errorf("Unexpected pagination %+v", p)
+	}
+}
+
+func TestPaginationEmptyLinks(t *testing.T) {
+	app := fiber.New()
+	app.Get("/items", func(c *fiber.Ctx) error {
+		response := NewPageResponse(PageRequest{Page: 1, Size: 20}, 0, []string{})
+		return response.ToResponse(c)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "http://api.test/items", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body PaginationResponse[string]
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Links{
+		First: "http://api.test/items?page=1&size=20",
+		Last:  "http://api.test/items?page=1&size=20",
+	}
+	if *body.Pagination.Links != expected || len(body.Elements) != 0 {
+		t.Errorf("Links must be %+v. Got %+v", expected, *body.Pagination.Links)
+	}
+}
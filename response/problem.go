@@ -0,0 +1,95 @@
+package response
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is the RFC 9457 representation of a ResponseError.
+// Extensions are rendered as top-level members
+type ProblemDetails struct {
+	Type       string         `json:"type"`
+	Title      string         `json:"title"`
+	Status     int            `json:"status"`
+	Detail     string         `json:"detail,omitempty"`
+	Instance   string         `json:"instance,omitempty"`
+	Code       ErrorCode      `json:"code,omitempty"`
+	Errors     []Error        `json:"errors,omitempty"`
+	TraceID    string         `json:"traceId,omitempty"`
+	Timestamp  *time.Time     `json:"timestamp,omitempty"`
+	Extensions map[string]any `json:"-"`
+}
+
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	type problem ProblemDetails
+	data, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return data, err
+	}
+
+	members := make(map[string]any)
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+	for key, value := range p.Extensions {
+		if _, ok := members[key]; !ok {
+			members[key] = value
+		}
+	}
+	return json.Marshal(members)
+}
+
+// Format returns the error format for the request: ProblemFormat when it
+// explicitly accepts application/problem+json (q > 0), otherwise the configured one
+func Format(c *fiber.Ctx) ErrorFormat {
+	for part := range strings.SplitSeq(c.Get(fiber.HeaderAccept), ",") {
+		mediaType, params, _ := strings.Cut(part, ";")
+		if strings.EqualFold(strings.TrimSpace(mediaType), ProblemContentType) && acceptQuality(params) > 0 {
+			return ProblemFormat
+		}
+	}
+	return cfg.format
+}
+
+// WithExtension adds a member rendered only in ProblemFormat
+func (re *ResponseError) WithExtension(key string, value any) *ResponseError {
+	if re.extensions == nil {
+		re.extensions = make(map[string]any)
+	}
+	re.extensions[key] = value
+	return re
+}
+
+// Problem converts the error to ProblemDetails. The first error is the
+// detail and, when there are several, all of them are in the errors member
+func (re ResponseError) Problem(c *fiber.Ctx) ProblemDetails {
+	first := re.Get()
+
+	problem := ProblemDetails{
+		Type:       "about:blank",
+		Title:      http.StatusText(first.Status()),
+		Status:     first.Status(),
+		Detail:     first.Message,
+		Instance:   c.OriginalURL(),
+		Code:       first.Code,
+		TraceID:    re.TraceID,
+		Timestamp:  re.Timestamp,
+		Extensions: maps.Clone(re.extensions),
+	}
+
+	if cfg.problemType != "" && first.Code != "" {
+		problem.Type = cfg.problemType + strings.ToLower(first.Code)
+	}
+
+	if len(re.Errors) > 1 {
+		problem.Errors = re.Errors
+	}
+	return problem
+}
@@ -12,7 +12,6 @@ import (
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/log"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/javiorfo/go-microservice-lib/response"
@@ -21,7 +20,51 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
-var tokenTracer = otel.Tracer("TokenSecurity")
+const tokenTracerName = "TokenSecurity"
+
+// Authentication errors, all of them with the AUTH_ERROR code
+var (
+	errMissingBearer = response.Register(response.Definition{
+		Key:        "AUTH_ERROR.missing_bearer",
+		Code:       "AUTH_ERROR",
+		HttpStatus: fiber.StatusUnauthorized,
+		Message:    "Authorization header or Bearer missing",
+		Translations: map[string]response.Message{
+			"es": "Falta el header Authorization o el Bearer",
+			"pt": "Cabeçalho Authorization ou Bearer ausente",
+		},
+	})
+	errInvalidToken = response.Register(response.Definition{
+		Key:        "AUTH_ERROR.invalid_token",
+		Code:       "AUTH_ERROR",
+		HttpStatus: fiber.StatusUnauthorized,
+		Message:    "Invalid or expired token",
+		Translations: map[string]response.Message{
+			"es": "Token inválido o expirado",
+			"pt": "Token inválido ou expirado",
+		},
+	})
+	errInvalidClaims = response.Register(response.Definition{
+		Key:        "AUTH_ERROR.invalid_claims",
+		Code:       "AUTH_ERROR",
+		HttpStatus: fiber.StatusUnauthorized,
+		Message:    "Invalid token",
+		Translations: map[string]response.Message{
+			"es": "Token inválido",
+			"pt": "Token inválido",
+		},
+	})
+	errForbidden = response.Register(response.Definition{
+		Key:        "AUTH_ERROR.forbidden",
+		Code:       "AUTH_ERROR",
+		HttpStatus: fiber.StatusUnauthorized,
+		Message:    "User does not have permission to access",
+		Translations: map[string]response.Message{
+			"es": "El usuario no tiene permiso de acceso",
+			"pt": "O usuário não tem permissão de acesso",
+		},
+	})
+)
 
 type TokenSecurity struct {
 	Enabled bool
@@ -52,21 +95,17 @@ type TokenPermission struct {
 // no role validation is executed
 func (t TokenSecurity) Secure(roles ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		_, span := tokenTracer.Start(c.UserContext(), "JWT Security")
+		ctx, span := otel.Tracer(tokenTracerName).Start(c.UserContext(), "JWT Security")
 		defer span.End()
 
 		if !t.Enabled {
-			log.Warn(tracing.LogInfo(span, "security disabled!"))
+			tracing.Logger().WarnContext(ctx, "security disabled!")
 			return c.Next()
 		}
 
 		authHeader := c.Get("Authorization")
 		if authHeader == "" || !strings.Contains(authHeader, "Bearer") {
-			authorizationHeaderError := response.NewResponseError(span, response.Error{
-				Code:    "AUTH_ERROR",
-				Message: "Authorization header or Bearer missing",
-			})
-			return c.Status(fiber.StatusUnauthorized).JSON(authorizationHeaderError)
+			return errMissingBearer.Response(span).ToResponse(c)
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
@@ -75,29 +114,17 @@ func (t TokenSecurity) Secure(roles ...string) fiber.Handler {
 		})
 
 		if err != nil || !token.Valid {
-			invalidTokenError := response.NewResponseError(span, response.Error{
-				Code:    "AUTH_ERROR",
-				Message: "Invalid or expired token",
-			})
-			return c.Status(fiber.StatusUnauthorized).JSON(invalidTokenError)
+			return errInvalidToken.Response(span).ToResponse(c)
 		}
 
 		claims, ok := token.Claims.(*TokenClaims)
 		if !ok {
-			invalidTokenError := response.NewResponseError(span, response.Error{
-				Code:    "AUTH_ERROR",
-				Message: "Invalid token",
-			})
-			return c.Status(fiber.StatusUnauthorized).JSON(invalidTokenError)
+			return errInvalidClaims.Response(span).ToResponse(c)
 		}
 
 		if len(roles) > 0 {
 			if ok := hasRole(claims.Permission, roles); !ok {
-				invalidTokenError := response.NewResponseError(span, response.Error{
-					Code:    "AUTH_ERROR",
-					Message: "User does not have permission to access",
-				})
-				return c.Status(fiber.StatusUnauthorized).JSON(invalidTokenError)
+				return errForbidden.Response(span).ToResponse(c)
 			}
 		}
 
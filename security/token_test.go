@@ -0,0 +1,81 @@
+package security
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/tracing/tracingtest"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func newSecuredApp(t TokenSecurity, roles ...string) *fiber.App {
+	app := fiber.New()
+	app.Get("/secured", t.Secure(roles...), func(c *fiber.Ctx) error {
+		return c.SendString(GetTokenUsername(c))
+	})
+	return app
+}
+
+func TestSecure(t *testing.T) {
+	t.Setenv("JWT_SECRET_KEY", "secret")
+	recorder := tracingtest.Install(t)
+	app := newSecuredApp(TokenSecurity{Enabled: true}, "ADMIN")
+
+	token, err := CreateTokenWithDuration(TokenPermission{Name: "app", Roles: []string{"ADMIN"}}, "john", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(fiber.MethodGet, "/secured", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Status code must be 200. Got %d", resp.StatusCode)
+	}
+
+	recorder.Span(t, "JWT Security").HasStatus(codes.Unset)
+}
+
+func TestSecureUnauthorized(t *testing.T) {
+	t.Setenv("JWT_SECRET_KEY", "secret")
+	recorder := tracingtest.Install(t)
+	app := newSecuredApp(TokenSecurity{Enabled: true})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/secured", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("Status code must be 401. Got %d", resp.StatusCode)
+	}
+
+	span := recorder.Span(t, "JWT Security").HasStatus(codes.Unset).HasEvent("Authorization header or Bearer missing")
+
+	logs := recorder.Logs(t)
+	if len(logs) != 1 || logs[0]["code"] != "AUTH_ERROR" || logs[0]["traceID"] != span.ReadOnlySpan().SpanContext().TraceID().String() {
+		t.Errorf("Must log the AUTH_ERROR with the trace ID. Got %v", logs)
+	}
+}
+
+func TestSecureDisabled(t *testing.T) {
+	recorder := tracingtest.Install(t)
+	app := newSecuredApp(TokenSecurity{Enabled: false})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/secured", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("Status code must be 200. Got %d", resp.StatusCode)
+	}
+
+	recorder.Span(t, "JWT Security").HasEvent("security disabled!")
+}
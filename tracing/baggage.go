@@ -0,0 +1,151 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync/atomic"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/nilo"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type baggageOption struct {
+	allowedKeys []string
+	maxMembers  int
+	maxBytes    int
+}
+
+type BaggageOptions func(*baggageOption)
+
+// WithAllowedKeys sets the only baggage keys sent by integration.Client,
+// in this order. If not set, no baggage is sent
+func WithAllowedKeys(keys ...string) BaggageOptions {
+	return func(o *baggageOption) {
+		o.allowedKeys = keys
+	}
+}
+
+// WithMaxMembers sets the maximum number of baggage members sent (64 if not set)
+func WithMaxMembers(max int) BaggageOptions {
+	return func(o *baggageOption) {
+		o.maxMembers = max
+	}
+}
+
+// WithMaxBytes sets the maximum size of the baggage header sent (4096 if not set)
+func WithMaxBytes(max int) BaggageOptions {
+	return func(o *baggageOption) {
+		o.maxBytes = max
+	}
+}
+
+var baggagePolicy atomic.Pointer[baggageOption]
+
+func init() {
+	ConfigureBaggage()
+}
+
+// ConfigureBaggage sets the allow-list and limits applied to the outgoing baggage
+func ConfigureBaggage(options ...BaggageOptions) {
+	opts := baggageOption{maxMembers: 64, maxBytes: 4096}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	baggagePolicy.Store(&opts)
+}
+
+// SetBaggage returns a context with the baggage member key=value
+func SetBaggage(ctx context.Context, key, value string) (context.Context, error) {
+	member, err := baggage.NewMemberRaw(key, value)
+	if err != nil {
+		return ctx, fmt.Errorf("invalid baggage member %s: %w", key, err)
+	}
+
+	b, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, fmt.Errorf("invalid baggage member %s: %w", key, err)
+	}
+	return baggage.ContextWithBaggage(ctx, b), nil
+}
+
+// GetBaggage returns the value of the baggage member key if present
+func GetBaggage(ctx context.Context, key string) nilo.Option[string] {
+	member := baggage.FromContext(ctx).Member(key)
+	if member.Key() == "" {
+		return nilo.Nil[string]()
+	}
+	return nilo.Value(member.Value())
+}
+
+// OutgoingBaggage returns a context whose baggage only has the allowed keys,
+// in allow-list order, within the configured limits. Used by integration.Client before sending
+func OutgoingBaggage(ctx context.Context) context.Context {
+	current := baggage.FromContext(ctx)
+	if current.Len() == 0 {
+		return ctx
+	}
+
+	policy := baggagePolicy.Load()
+
+	var members []baggage.Member
+	size := 0
+	for _, key := range policy.allowedKeys {
+		member := current.Member(key)
+		if member.Key() == "" || slices.ContainsFunc(members, func(m baggage.Member) bool { return m.Key() == key }) {
+			continue
+		}
+
+		// +1 for the comma separator
+		memberSize := len(member.String()) + 1
+		if len(members) == policy.maxMembers || size+memberSize > policy.maxBytes {
+			Logger().WarnContext(ctx, "baggage member dropped by limits", "key", member.Key())
+			continue
+		}
+
+		members = append(members, member)
+		size += memberSize
+	}
+
+	filtered, _ := baggage.New(members...)
+	return baggage.ContextWithBaggage(ctx, filtered)
+}
+
+// BaggageMiddleware promotes the given baggage keys to attributes of the
+// current span and to fields of the records logged with c.UserContext().
+// It must be registered after Middleware
+func BaggageMiddleware(keys ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.UserContext()
+		if baggage.FromContext(ctx).Len() == 0 {
+			ctx = GetContextPropagator(c)
+		}
+
+		b := baggage.FromContext(ctx)
+		span := trace.SpanFromContext(ctx)
+
+		var fields []slog.Attr
+		for _, key := range keys {
+			member := b.Member(key)
+			if member.Key() == "" {
+				continue
+			}
+			span.SetAttributes(attribute.String("baggage."+key, member.Value()))
+			fields = append(fields, slog.String(key, member.Value()))
+		}
+
+		if len(fields) > 0 {
+			ctx = ContextWithLogFields(ctx, fields...)
+		}
+
+		userCtx := c.UserContext()
+		c.SetUserContext(ctx)
+		defer c.SetUserContext(userCtx)
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+func TestBaggage(t *testing.T) {
+	ctx, err := SetBaggage(t.Context(), "tenant", "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, _ = SetBaggage(ctx, "user.email", "john@acme.com")
+	ctx, _ = SetBaggage(ctx, "region", "eu")
+
+	if GetBaggage(ctx, "tenant").Or("") != "acme" {
+		t.Error("tenant must be acme")
+	}
+
+	if !GetBaggage(ctx, "missing").IsNil() {
+		t.Error("missing must be nil")
+	}
+
+	if _, err := SetBaggage(ctx, "", "value"); err == nil {
+		t.Error("empty key must be invalid")
+	}
+
+	if outgoing := baggage.FromContext(OutgoingBaggage(ctx)); outgoing.Len() != 0 {
+		t.Errorf("Outgoing baggage must be empty without allow-list. Got %s", outgoing)
+	}
+
+	ConfigureBaggage(WithAllowedKeys("region", "tenant"), WithMaxMembers(1))
+	t.Cleanup(func() { ConfigureBaggage() })
+
+	for range 10 {
+		outgoing := baggage.FromContext(OutgoingBaggage(ctx))
+		if outgoing.Len() != 1 || outgoing.Member("region").Value() != "eu" {
+			t.Fatalf("Outgoing baggage must have the first allowed member. Got %s", outgoing)
+		}
+	}
+}
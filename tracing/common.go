@@ -9,10 +9,16 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// LogError sets the span status to error and returns msg prefixed with the trace and span IDs
+//
+// Deprecated: use Logger().ErrorContext(SpanContext(span), msg)
 func LogError(span trace.Span, msg string) string {
 	return log(span, msg, true)
 }
 
+// LogInfo adds msg as a span event and returns it prefixed with the trace and span IDs
+//
+// Deprecated: use Logger().InfoContext(SpanContext(span), msg)
 func LogInfo(span trace.Span, msg string) string {
 	return log(span, msg, false)
 }
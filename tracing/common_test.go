@@ -0,0 +1,43 @@
+package tracing_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/javiorfo/go-microservice-lib/tracing"
+	"github.com/javiorfo/go-microservice-lib/tracing/tracingtest"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestLogError(t *testing.T) {
+	recorder := tracingtest.Install(t)
+
+	_, span := otel.Tracer("test").Start(t.Context(), "handler")
+	msg := tracing.LogError(span, "failed")
+	span.End()
+
+	if !strings.HasPrefix(msg, "[traceID: "+span.SpanContext().TraceID().String()) || !strings.HasSuffix(msg, "] failed") {
+		t.Errorf("Message must have the trace and span IDs. Got %s", msg)
+	}
+
+	recorder.Span(t, "handler").HasStatus(codes.Error)
+}
+
+func TestLogInfo(t *testing.T) {
+	recorder := tracingtest.Install(t)
+
+	ctx, parent := otel.Tracer("test").Start(t.Context(), "parent")
+	_, span := otel.Tracer("test").Start(ctx, "child")
+	tracing.LogInfo(span, "done")
+	span.End()
+	parent.End()
+
+	recorder.Span(t, "child").HasEvent("done").HasStatus(codes.Unset).HasParent("parent")
+	recorder.Span(t, "parent").IsRoot()
+
+	if msg := tracing.LogInfo(noop.Span{}, "done"); msg != "done" {
+		t.Errorf("Message without span must not be prefixed. Got %s", msg)
+	}
+}
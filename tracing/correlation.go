@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const RequestIDHeader = "X-Request-Id"
+
+// CorrelationMiddleware adds the traceparent of the current span and the
+// X-Request-Id (the incoming one or a new UUID) to every response. The request
+// ID is also added to the records logged with c.UserContext().
+// It must be registered after Middleware
+func CorrelationMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		requestID := c.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+		c.Set(RequestIDHeader, requestID)
+
+		userCtx := c.UserContext()
+
+		carrier := propagation.MapCarrier{}
+		propagation.TraceContext{}.Inject(userCtx, carrier)
+		for header, value := range carrier {
+			c.Set(header, value)
+		}
+
+		c.SetUserContext(ContextWithLogFields(userCtx, slog.String("requestID", requestID)))
+		defer c.SetUserContext(userCtx)
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type logOption struct {
+	level  slog.Leveler
+	fields []slog.Attr
+}
+
+type LogOptions func(*logOption)
+
+// WithLevel sets the minimum level logged. If not set, LOG_LEVEL is honored, falling back to INFO
+func WithLevel(level slog.Leveler) LogOptions {
+	return func(o *logOption) {
+		o.level = level
+	}
+}
+
+// WithService adds the service field to every record. If not set, OTEL_SERVICE_NAME is used
+func WithService(name string) LogOptions {
+	return WithField("service", name)
+}
+
+// WithField adds a fixed field to every record (e.g. version or environment)
+func WithField(key string, value any) LogOptions {
+	return func(o *logOption) {
+		o.fields = append(o.fields, slog.Any(key, value))
+	}
+}
+
+// LogHandler is a slog.Handler writing JSON records with the traceID and
+// spanID of the span in the context. Errors set the span status to error,
+// the rest of the records are added as span events
+type LogHandler struct {
+	slog.Handler
+}
+
+func NewLogHandler(w io.Writer, options ...LogOptions) *LogHandler {
+	opts := logOption{}
+
+	if service := os.Getenv("OTEL_SERVICE_NAME"); service != "" {
+		opts.fields = append(opts.fields, slog.String("service", service))
+	}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	if opts.level == nil {
+		opts.level = levelFromEnv()
+	}
+
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: opts.level})
+	return &LogHandler{handler.WithAttrs(opts.fields)}
+}
+
+func (h *LogHandler) Handle(ctx context.Context, record slog.Record) error {
+	record.AddAttrs(LogFields(ctx)...)
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		record.AddAttrs(
+			slog.String("traceID", span.SpanContext().TraceID().String()),
+			slog.String("spanID", span.SpanContext().SpanID().String()),
+		)
+
+		if record.Level >= slog.LevelError {
+			span.SetStatus(codes.Error, record.Message)
+		} else {
+			span.AddEvent(record.Message)
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LogHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h *LogHandler) WithGroup(name string) slog.Handler {
+	return &LogHandler{h.Handler.WithGroup(name)}
+}
+
+func levelFromEnv() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.ToUpper(os.Getenv("LOG_LEVEL")))); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+var logger = slog.New(NewLogHandler(os.Stdout))
+
+// Logger returns the logger used by the library
+func Logger() *slog.Logger {
+	return logger
+}
+
+// SetLogger replaces the logger used by the library
+// (e.g. tracing.SetLogger(slog.New(tracing.NewLogHandler(os.Stdout, tracing.WithService("app")))))
+func SetLogger(l *slog.Logger) {
+	logger = l
+}
+
+type logFieldsKey struct{}
+
+// ContextWithLogFields returns a context whose records logged by LogHandler include fields
+func ContextWithLogFields(ctx context.Context, fields ...slog.Attr) context.Context {
+	current, _ := ctx.Value(logFieldsKey{}).([]slog.Attr)
+	return context.WithValue(ctx, logFieldsKey{}, append(slices.Clip(current), fields...))
+}
+
+// LogFields returns the fields added to ctx by ContextWithLogFields
+func LogFields(ctx context.Context) []slog.Attr {
+	fields, _ := ctx.Value(logFieldsKey{}).([]slog.Attr)
+	return fields
+}
+
+// SpanContext returns a context carrying span, to log with the *Context methods of Logger
+func SpanContext(span trace.Span) context.Context {
+	return trace.ContextWithSpan(context.Background(), span)
+}
@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestLogHandler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := trace.NewTracerProvider(trace.WithSpanProcessor(recorder))
+
+	ctx, span := tp.Tracer("test").Start(t.Context(), "handler")
+
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(&buf, WithService("app"), WithLevel(slog.LevelDebug)))
+	logger.ErrorContext(ctx, "something failed", "code", "ERR")
+	span.End()
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+
+	if record["traceID"] != span.SpanContext().TraceID().String() {
+		t.Errorf("traceID must be %s. Got %v", span.SpanContext().TraceID(), record["traceID"])
+	}
+	if record["service"] != "app" || record["code"] != "ERR" || record["msg"] != "something failed" {
+		t.Errorf("Unexpected record %v", record)
+	}
+
+	status := recorder.Ended()[0].Status()
+	if status.Code != codes.Error || status.Description != "something failed" {
+		t.Errorf("Span status must be error. Got %v", status)
+	}
+}
+
+func TestLogLevelFromEnv(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn")
+
+	var buf bytes.Buffer
+	logger := slog.New(NewLogHandler(&buf))
+	logger.Info("skipped")
+
+	if buf.Len() != 0 {
+		t.Errorf("Info must be skipped with LOG_LEVEL=warn. Got %s", buf.String())
+	}
+}
@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/internal/errorhandler"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serverTracerName = "FiberServer"
+
+type middlewareOption struct {
+	excludedPaths []string
+	filter        func(*fiber.Ctx) bool
+}
+
+type MiddlewareOptions func(*middlewareOption)
+
+// WithExcludedPaths skips tracing for the given request paths (e.g. /health)
+func WithExcludedPaths(paths ...string) MiddlewareOptions {
+	return func(o *middlewareOption) {
+		o.excludedPaths = append(o.excludedPaths, paths...)
+	}
+}
+
+// WithFilter skips tracing when filter returns false
+func WithFilter(filter func(*fiber.Ctx) bool) MiddlewareOptions {
+	return func(o *middlewareOption) {
+		o.filter = filter
+	}
+}
+
+// Middleware creates a server span per request named by route template.
+// The span context is stored in c.UserContext() for the next handlers.
+// Errors are handled here by the app ErrorHandler and not returned
+func Middleware(options ...MiddlewareOptions) fiber.Handler {
+	opts := middlewareOption{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	return func(c *fiber.Ctx) error {
+		if slices.Contains(opts.excludedPaths, c.Path()) || (opts.filter != nil && !opts.filter(c)) {
+			return c.Next()
+		}
+
+		ctx, span := otel.Tracer(serverTracerName).Start(GetContextPropagator(c), c.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+				semconv.URLScheme(c.Protocol()),
+				semconv.ClientAddress(c.IP()),
+				semconv.UserAgentOriginal(c.Get(fiber.HeaderUserAgent)),
+			),
+		)
+		defer span.End()
+
+		userCtx := c.UserContext()
+		c.SetUserContext(ctx)
+		defer c.SetUserContext(userCtx)
+
+		// the error handler records the error (see backend.ErrorHandler)
+		errorhandler.Handle(c, c.Next())
+
+		route := c.Route().Path
+		status := c.Response().StatusCode()
+
+		span.SetName(fmt.Sprintf("%s %s", c.Method(), route))
+		span.SetAttributes(
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		)
+
+		if principal, ok := c.Locals("tokenUser").(string); ok {
+			span.SetAttributes(semconv.EnduserID(principal))
+		}
+
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return nil
+	}
+}
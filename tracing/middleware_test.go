@@ -0,0 +1,52 @@
+package tracing
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func TestMiddleware(t *testing.T) {
+	provider := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(trace.NewTracerProvider(trace.WithSpanProcessor(recorder)))
+
+	app := fiber.New()
+	app.Use(Middleware(WithExcludedPaths("/health")))
+	app.Get("/health", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Get("/items/:id", func(c *fiber.Ctx) error { return c.SendString(c.Params("id")) })
+	app.Get("/fail", func(c *fiber.Ctx) error { return fiber.ErrServiceUnavailable })
+
+	for _, path := range []string{"/health", "/items/1", "/fail"} {
+		if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans. Got %d", len(spans))
+	}
+
+	if spans[0].Name() != "GET /items/:id" {
+		t.Errorf("Span name must be 'GET /items/:id'. Got %s", spans[0].Name())
+	}
+
+	for _, attr := range spans[0].Attributes() {
+		if attr.Key == semconv.HTTPResponseStatusCodeKey && attr.Value.AsInt64() != fiber.StatusOK {
+			t.Errorf("Status code must be 200. Got %d", attr.Value.AsInt64())
+		}
+	}
+
+	if spans[1].Status().Code != codes.Error {
+		t.Error("5xx must be marked as error")
+	}
+}
@@ -0,0 +1,210 @@
+package tracing
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/javiorfo/go-microservice-lib/internal/otlpconfig"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+// Exporter identifies where the spans are sent
+type Exporter string
+
+const (
+	OtlpHttpExporter Exporter = "otlphttp"
+	OtlpGrpcExporter Exporter = "otlpgrpc"
+	StdoutExporter   Exporter = "stdout"
+	FileExporter     Exporter = "file"
+	NoopExporter     Exporter = "none"
+)
+
+type tracingOption struct {
+	exporter     Exporter
+	otlp         otlpconfig.Config
+	filePath     string
+	sampler      trace.Sampler
+	sampling     *SamplingConfig
+	batchOptions []trace.BatchSpanProcessorOption
+	resource     []ResourceOptions
+	propagators  []Propagator
+}
+
+type TracingOptions func(*tracingOption)
+
+// WithExporter sets the span exporter. If not set, OTEL_TRACES_EXPORTER
+// and OTEL_EXPORTER_OTLP_PROTOCOL are honored, falling back to OTLP/HTTP
+func WithExporter(exporter Exporter) TracingOptions {
+	return func(o *tracingOption) {
+		o.exporter = exporter
+	}
+}
+
+// WithEndpoint sets the OTLP collector endpoint (host:port), secured with TLS
+// unless WithInsecure is set. If not set, TRACING_HOST (insecure) or the
+// OTEL_EXPORTER_OTLP_* variables are used
+func WithEndpoint(endpoint string) TracingOptions {
+	return func(o *tracingOption) {
+		o.otlp.Endpoint = endpoint
+	}
+}
+
+// WithInsecure disables TLS on the OTLP connection
+func WithInsecure() TracingOptions {
+	return func(o *tracingOption) {
+		o.otlp.SetInsecure()
+	}
+}
+
+// WithTLS enables TLS on the OTLP connection with the given config
+func WithTLS(config *tls.Config) TracingOptions {
+	return func(o *tracingOption) {
+		o.otlp.SetTLS(config)
+	}
+}
+
+// WithHeader adds a header (e.g. Authorization) sent on every OTLP export
+func WithHeader(name, value string) TracingOptions {
+	return func(o *tracingOption) {
+		o.otlp.SetHeader(name, value)
+	}
+}
+
+// WithFile writes the spans as JSON into the file at path
+func WithFile(path string) TracingOptions {
+	return func(o *tracingOption) {
+		o.exporter = FileExporter
+		o.filePath = path
+	}
+}
+
+// WithSampler sets the sampler. If not set, OTEL_TRACES_SAMPLER
+// and OTEL_TRACES_SAMPLER_ARG are honored, falling back to always-on.
+// TRACING_SAMPLING_CONFIG may point to a JSON file with sampling rules
+func WithSampler(sampler trace.Sampler) TracingOptions {
+	return func(o *tracingOption) {
+		o.sampler = sampler
+		o.sampling = nil
+	}
+}
+
+// WithSampleRatio samples the given fraction of root traces and
+// follows the parent decision for the rest
+func WithSampleRatio(ratio float64) TracingOptions {
+	return WithSampler(trace.ParentBased(trace.TraceIDRatioBased(ratio)))
+}
+
+// WithBatchOptions configures the batch span processor.
+// If not set, the OTEL_BSP_* variables are honored
+func WithBatchOptions(options ...trace.BatchSpanProcessorOption) TracingOptions {
+	return func(o *tracingOption) {
+		o.batchOptions = append(o.batchOptions, options...)
+	}
+}
+
+// WithResourceOptions sets the service version, environment and custom resource attributes
+func WithResourceOptions(options ...ResourceOptions) TracingOptions {
+	return func(o *tracingOption) {
+		o.resource = append(o.resource, options...)
+	}
+}
+
+func newTracingOption(options ...TracingOptions) tracingOption {
+	opts := tracingOption{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	if opts.exporter == "" {
+		opts.exporter = exporterFromEnv()
+	}
+
+	opts.otlp.FromEnv("TRACES", "TRACING_HOST")
+
+	if opts.propagators == nil {
+		opts.propagators = propagatorsFromEnv()
+	}
+
+	if opts.exporter == NoopExporter && opts.sampler == nil {
+		opts.sampler = trace.NeverSample()
+	}
+
+	return opts
+}
+
+func exporterFromEnv() Exporter {
+	switch strings.ToLower(os.Getenv("OTEL_TRACES_EXPORTER")) {
+	case "none":
+		return NoopExporter
+	case "console":
+		return StdoutExporter
+	}
+
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	if protocol == "grpc" {
+		return OtlpGrpcExporter
+	}
+	return OtlpHttpExporter
+}
+
+func (o tracingOption) newExporter(ctx context.Context) (trace.SpanExporter, error) {
+	switch o.exporter {
+	case OtlpHttpExporter:
+		return otlptrace.New(ctx, otlptracehttp.NewClient(o.httpOptions()...))
+	case OtlpGrpcExporter:
+		return otlptrace.New(ctx, otlptracegrpc.NewClient(o.grpcOptions()...))
+	case StdoutExporter:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case FileExporter:
+		file, err := os.OpenFile(o.filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		return fileExporter{exporter, file}, nil
+	case NoopExporter:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown exporter %q", o.exporter)
+}
+
+func (o tracingOption) httpOptions() []otlptracehttp.Option {
+	return otlpconfig.Options(o.otlp, otlptracehttp.WithEndpoint, otlptracehttp.WithHeaders,
+		otlptracehttp.WithInsecure, otlptracehttp.WithTLSClientConfig)
+}
+
+func (o tracingOption) grpcOptions() []otlptracegrpc.Option {
+	return otlpconfig.Options(o.otlp, otlptracegrpc.WithEndpoint, otlptracegrpc.WithHeaders,
+		otlptracegrpc.WithInsecure, func(config *tls.Config) otlptracegrpc.Option {
+			return otlptracegrpc.WithTLSCredentials(credentials.NewTLS(config))
+		})
+}
+
+// fileExporter closes the underlying file on shutdown
+type fileExporter struct {
+	trace.SpanExporter
+	file *os.File
+}
+
+func (f fileExporter) Shutdown(ctx context.Context) error {
+	if err := f.SpanExporter.Shutdown(ctx); err != nil {
+		f.file.Close()
+		return err
+	}
+	return f.file.Close()
+}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+func TestExporterFromEnv(t *testing.T) {
+	tests := []struct {
+		exporter string
+		protocol string
+		expected Exporter
+	}{
+		{"", "", OtlpHttpExporter},
+		{"otlp", "grpc", OtlpGrpcExporter},
+		{"otlp", "http/protobuf", OtlpHttpExporter},
+		{"console", "", StdoutExporter},
+		{"none", "", NoopExporter},
+	}
+
+	for _, tt := range tests {
+		t.Setenv("OTEL_TRACES_EXPORTER", tt.exporter)
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", tt.protocol)
+
+		if got := newTracingOption().exporter; got != tt.expected {
+			t.Errorf("exporter %q, protocol %q: expected %s. Got %s", tt.exporter, tt.protocol, tt.expected, got)
+		}
+	}
+}
+
+func TestTracingHostIsInsecure(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("TRACING_HOST", "localhost:4318")
+
+	opts := newTracingOption()
+	if opts.otlp.Endpoint != "localhost:4318" {
+		t.Errorf("endpoint must be localhost:4318. Got %s", opts.otlp.Endpoint)
+	}
+	if opts.otlp.Insecure == nil || !*opts.otlp.Insecure {
+		t.Error("TRACING_HOST must be insecure")
+	}
+
+	opts = newTracingOption(WithExporter(OtlpGrpcExporter), WithTLS(nil), WithHeader("Authorization", "token"))
+	if *opts.otlp.Insecure {
+		t.Error("WithTLS must not be insecure")
+	}
+	if opts.otlp.Headers["Authorization"] != "token" {
+		t.Error("Authorization header must be set")
+	}
+}
+
+func TestEndpointIsSecure(t *testing.T) {
+	t.Setenv("TRACING_HOST", "")
+
+	if opts := newTracingOption(WithEndpoint("collector:4318")); opts.otlp.Insecure != nil && *opts.otlp.Insecure {
+		t.Error("WithEndpoint must be secure by default")
+	}
+	if opts := newTracingOption(WithEndpoint("collector:4318"), WithInsecure()); opts.otlp.Insecure == nil || !*opts.otlp.Insecure {
+		t.Error("WithEndpoint and WithInsecure must be insecure")
+	}
+}
+
+func TestNoopExporterNeverSamples(t *testing.T) {
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+
+	tp, err := StartTracing("test", WithExporter(NoopExporter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tp.Shutdown(t.Context())
+
+	_, span := tp.Tracer("test").Start(t.Context(), "noop")
+	defer span.End()
+
+	if span.IsRecording() {
+		t.Error("noop exporter must not record spans")
+	}
+}
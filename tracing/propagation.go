@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Propagator identifies a trace context header format
+type Propagator string
+
+const (
+	TraceContextPropagator Propagator = "tracecontext"
+	BaggagePropagator      Propagator = "baggage"
+	B3Propagator           Propagator = "b3"
+	B3MultiPropagator      Propagator = "b3multi"
+	JaegerPropagator       Propagator = "jaeger"
+	XRayPropagator         Propagator = "xray"
+)
+
+var defaultPropagators = []Propagator{TraceContextPropagator, BaggagePropagator}
+
+// WithPropagators sets the header formats extracted by GetContextPropagator and
+// injected by integration.Client. If not set, OTEL_PROPAGATORS is honored
+// (comma separated), falling back to W3C TraceContext and Baggage
+func WithPropagators(propagators ...Propagator) TracingOptions {
+	return func(o *tracingOption) {
+		o.propagators = propagators
+	}
+}
+
+// NewPropagator creates a composite propagator with the given formats.
+// B3 extracts both single and multi header, b3multi only changes the injection
+func NewPropagator(propagators ...Propagator) (propagation.TextMapPropagator, error) {
+	composite := make([]propagation.TextMapPropagator, 0, len(propagators))
+	for _, p := range propagators {
+		switch p {
+		case TraceContextPropagator:
+			composite = append(composite, propagation.TraceContext{})
+		case BaggagePropagator:
+			composite = append(composite, propagation.Baggage{})
+		case B3Propagator:
+			composite = append(composite, b3.New(b3.WithInjectEncoding(b3.B3SingleHeader)))
+		case B3MultiPropagator:
+			composite = append(composite, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		case JaegerPropagator:
+			composite = append(composite, jaeger.Jaeger{})
+		case XRayPropagator:
+			composite = append(composite, xray.Propagator{})
+		default:
+			return nil, fmt.Errorf("unknown propagator %q", p)
+		}
+	}
+	return propagation.NewCompositeTextMapPropagator(composite...), nil
+}
+
+func propagatorsFromEnv() []Propagator {
+	env := os.Getenv("OTEL_PROPAGATORS")
+	if env == "" {
+		return defaultPropagators
+	}
+
+	propagators := make([]Propagator, 0)
+	for name := range strings.SplitSeq(strings.ToLower(env), ",") {
+		p := Propagator(strings.TrimSpace(name))
+		if p != "none" && !slices.Contains(propagators, p) {
+			propagators = append(propagators, p)
+		}
+	}
+	return propagators
+}
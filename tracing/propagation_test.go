@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPropagators(t *testing.T) {
+	provider, propagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagator)
+	})
+
+	tp, err := StartTracing("test", WithExporter(NoopExporter), WithPropagators(B3Propagator, JaegerPropagator))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tp.Shutdown(t.Context())
+
+	traceID := "463ac35c9f6413ad48485a3953bb6124"
+
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		ctx := GetContextPropagator(c)
+
+		if got := trace.SpanContextFromContext(ctx).TraceID().String(); got != traceID {
+			t.Errorf("Extracted trace ID must be %s. Got %s", traceID, got)
+		}
+
+		headers := http.Header{}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(headers))
+		if headers.Get("b3") == "" || headers.Get("uber-trace-id") == "" {
+			t.Errorf("B3 and Jaeger headers must be injected. Got %v", headers)
+		}
+		return nil
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Set("X-B3-TraceId", traceID)
+	req.Header.Set("X-B3-SpanId", "0020000000000001")
+	req.Header.Set("X-B3-Sampled", "1")
+	if _, err := app.Test(req); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewPropagator("unknown"); err == nil {
+		t.Error("Unknown propagator must fail")
+	}
+}
@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+type resourceOption struct {
+	version     string
+	environment string
+	attrs       []attribute.KeyValue
+}
+
+type ResourceOptions func(*resourceOption)
+
+// WithServiceVersion sets service.version. If not set, SERVICE_VERSION is used
+func WithServiceVersion(version string) ResourceOptions {
+	return func(o *resourceOption) {
+		o.version = version
+	}
+}
+
+// WithEnvironment sets deployment.environment. If not set, DEPLOYMENT_ENVIRONMENT is used
+func WithEnvironment(environment string) ResourceOptions {
+	return func(o *resourceOption) {
+		o.environment = environment
+	}
+}
+
+// WithResourceAttributes adds custom attributes, overriding the detected ones
+func WithResourceAttributes(attrs ...attribute.KeyValue) ResourceOptions {
+	return func(o *resourceOption) {
+		o.attrs = append(o.attrs, attrs...)
+	}
+}
+
+// NewResource describes the service with its name, version and environment plus the
+// host, OS, process, container and Kubernetes attributes detected. Attributes in
+// OTEL_RESOURCE_ATTRIBUTES override the detected ones. Kubernetes attributes are
+// read from K8S_POD_NAME, K8S_NAMESPACE_NAME and K8S_NODE_NAME (e.g. set by the Downward API)
+func NewResource(ctx context.Context, appName string, options ...ResourceOptions) (*resource.Resource, error) {
+	opts := resourceOption{
+		version:     os.Getenv("SERVICE_VERSION"),
+		environment: os.Getenv("DEPLOYMENT_ENVIRONMENT"),
+	}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	var service []attribute.KeyValue
+	if appName != "" {
+		service = append(service, semconv.ServiceName(appName))
+	}
+	if opts.version != "" {
+		service = append(service, semconv.ServiceVersion(opts.version))
+	}
+	if opts.environment != "" {
+		service = append(service, semconv.DeploymentEnvironment(opts.environment))
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithOS(),
+		resource.WithProcessPID(),
+		resource.WithProcessExecutableName(),
+		resource.WithProcessOwner(),
+		resource.WithProcessRuntimeName(),
+		resource.WithProcessRuntimeVersion(),
+		resource.WithContainer(),
+		resource.WithAttributes(kubernetesAttributes()...),
+		resource.WithFromEnv(),
+		resource.WithAttributes(service...),
+		resource.WithAttributes(opts.attrs...),
+	)
+
+	// a partial resource is still usable (e.g. container.id outside a container)
+	if errors.Is(err, resource.ErrPartialResource) {
+		Logger().WarnContext(ctx, "resource partially detected", "error", err)
+		return res, nil
+	}
+	return res, err
+}
+
+func kubernetesAttributes() []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if pod := os.Getenv("K8S_POD_NAME"); pod != "" {
+		attrs = append(attrs, semconv.K8SPodName(pod))
+	}
+	if namespace := os.Getenv("K8S_NAMESPACE_NAME"); namespace != "" {
+		attrs = append(attrs, semconv.K8SNamespaceName(namespace))
+	}
+	if node := os.Getenv("K8S_NODE_NAME"); node != "" {
+		attrs = append(attrs, semconv.K8SNodeName(node))
+	}
+	return attrs
+}
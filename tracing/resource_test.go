@@ -0,0 +1,43 @@
+package tracing
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func TestNewResource(t *testing.T) {
+	t.Setenv("SERVICE_VERSION", "1.0.0")
+	t.Setenv("DEPLOYMENT_ENVIRONMENT", "dev")
+	t.Setenv("K8S_NAMESPACE_NAME", "payments")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "team=core")
+
+	res, err := NewResource(t.Context(), "app",
+		WithEnvironment("production"),
+		WithResourceAttributes(attribute.String("region", "eu")),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[attribute.Key]string{
+		semconv.ServiceNameKey:           "app",
+		semconv.ServiceVersionKey:        "1.0.0",
+		semconv.DeploymentEnvironmentKey: "production",
+		semconv.K8SNamespaceNameKey:      "payments",
+		"team":                           "core",
+		"region":                         "eu",
+	}
+
+	set := res.Set()
+	for key, value := range expected {
+		if got, ok := set.Value(key); !ok || got.AsString() != value {
+			t.Errorf("%s must be %s. Got %s", key, value, got.AsString())
+		}
+	}
+
+	if _, ok := set.Value(semconv.HostNameKey); !ok {
+		t.Error("host.name must be detected")
+	}
+}
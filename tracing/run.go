@@ -0,0 +1,106 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AttributedError is an error that adds attributes to the span recording it
+// (e.g. response.ResponseError adds its error codes and HTTP status)
+type AttributedError interface {
+	error
+	Attributes() []attribute.KeyValue
+}
+
+type spanOption struct {
+	tracer string
+	kind   trace.SpanKind
+	links  []trace.Link
+	attrs  []attribute.KeyValue
+}
+
+type SpanOptions func(*spanOption)
+
+// WithTracer sets the tracer name ("Tracing" if not set)
+func WithTracer(name string) SpanOptions {
+	return func(o *spanOption) {
+		o.tracer = name
+	}
+}
+
+// WithSpanKind sets the span kind (internal if not set)
+func WithSpanKind(kind trace.SpanKind) SpanOptions {
+	return func(o *spanOption) {
+		o.kind = kind
+	}
+}
+
+// WithLinks links the span to other spans (e.g. the producer of a message)
+func WithLinks(links ...trace.Link) SpanOptions {
+	return func(o *spanOption) {
+		o.links = append(o.links, links...)
+	}
+}
+
+// WithSpanAttributes sets attributes on the span at start
+func WithSpanAttributes(attrs ...attribute.KeyValue) SpanOptions {
+	return func(o *spanOption) {
+		o.attrs = append(o.attrs, attrs...)
+	}
+}
+
+// Run executes fn inside a span named name. A returned error is recorded and
+// sets the span status to error. A panic is recorded with its stack trace
+// and panics again after ending the span
+func Run(ctx context.Context, name string, fn func(context.Context) error, options ...SpanOptions) error {
+	_, err := RunValue(ctx, name, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	}, options...)
+	return err
+}
+
+// RunValue is like Run for functions returning a value
+func RunValue[T any](ctx context.Context, name string, fn func(context.Context) (T, error), options ...SpanOptions) (T, error) {
+	opts := spanOption{tracer: "Tracing"}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	ctx, span := otel.Tracer(opts.tracer).Start(ctx, name,
+		trace.WithSpanKind(opts.kind),
+		trace.WithLinks(opts.links...),
+		trace.WithAttributes(opts.attrs...),
+	)
+	// on panic End records the exception event with the stack trace
+	defer span.End(trace.WithStackTrace(true))
+
+	defer func() {
+		if p := recover(); p != nil {
+			span.SetStatus(codes.Error, fmt.Sprintf("panic: %v", p))
+			panic(p)
+		}
+	}()
+
+	value, err := fn(ctx)
+	if err != nil {
+		RecordError(span, err)
+	}
+	return value, err
+}
+
+// RecordError records err on span and sets the span status to error.
+// If err wraps an AttributedError its attributes are added to the span
+func RecordError(span trace.Span, err error) {
+	var attributed AttributedError
+	if errors.As(err, &attributed) {
+		span.SetAttributes(attributed.Attributes()...)
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
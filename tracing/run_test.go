@@ -0,0 +1,71 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type codeError string
+
+func (e codeError) Error() string { return string(e) }
+
+func (e codeError) Attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String("error.code", string(e))}
+}
+
+func TestRun(t *testing.T) {
+	provider := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(trace.NewTracerProvider(trace.WithSpanProcessor(recorder)))
+
+	err := Run(t.Context(), "failing", func(ctx context.Context) error {
+		return fmt.Errorf("wrapped: %w", codeError("NOT_FOUND"))
+	})
+	if err == nil {
+		t.Fatal("Must return the error")
+	}
+
+	value, err := RunValue(t.Context(), "value", func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	if err != nil || value != 1 {
+		t.Errorf("Must return 1 without error. Got %d, %v", value, err)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Must panic again")
+			}
+		}()
+		Run(t.Context(), "panicking", func(ctx context.Context) error {
+			panic("boom")
+		})
+	}()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans. Got %d", len(spans))
+	}
+
+	if spans[0].Status().Code != codes.Error || spans[0].Attributes()[0].Value.AsString() != "NOT_FOUND" {
+		t.Errorf("Span must have error status and code attribute. Got %v %v", spans[0].Status(), spans[0].Attributes())
+	}
+
+	if spans[1].Status().Code == codes.Error {
+		t.Error("Span must not have error status")
+	}
+
+	if spans[2].Status().Code != codes.Error || len(spans[2].Events()) != 1 {
+		t.Errorf("Panic must be recorded. Got %v", spans[2].Events())
+	}
+}
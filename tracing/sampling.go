@@ -0,0 +1,227 @@
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// SamplingRule matches spans by route, method, status and attributes. Empty
+// fields match everything. Route is a path template (e.g. /items/:id or /api/*)
+// matched against http.route or url.path. Rules with MinStatus are evaluated when
+// the span ends, so they only apply with RecordErrors. Ratio is the fraction
+// of matching traces kept, decided by trace ID as in TraceIDRatioBased
+type SamplingRule struct {
+	Route      string            `json:"route"`
+	Method     string            `json:"method"`
+	MinStatus  int               `json:"minStatus"`
+	Attributes map[string]string `json:"attributes"`
+	Ratio      float64           `json:"ratio"`
+}
+
+// SamplingConfig holds the rules evaluated in order; the first match decides.
+// Spans matching no rule are sampled with DefaultRatio. With RecordErrors the
+// spans not sampled are still recorded, and exported if they end with error
+// status or match a MinStatus rule
+type SamplingConfig struct {
+	Rules        []SamplingRule `json:"rules"`
+	DefaultRatio float64        `json:"defaultRatio"`
+	RecordErrors bool           `json:"recordErrors"`
+}
+
+// LoadSamplingConfig reads a SamplingConfig from a JSON file, e.g.
+//
+//	{"defaultRatio": 1, "recordErrors": true, "rules": [
+//	  {"route": "/health", "ratio": 0},
+//	  {"route": "/items", "method": "GET", "ratio": 0.01}
+//	]}
+func LoadSamplingConfig(path string) (SamplingConfig, error) {
+	var config SamplingConfig
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+
+	if err := json.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("invalid sampling config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// WithSamplingRules samples the spans with a RuleSampler
+func WithSamplingRules(config SamplingConfig) TracingOptions {
+	return func(o *tracingOption) {
+		o.sampler = NewRuleSampler(config)
+		o.sampling = &config
+	}
+}
+
+type ruleSampler struct {
+	config   SamplingConfig
+	samplers []trace.Sampler
+	fallback trace.Sampler
+}
+
+// NewRuleSampler creates a Sampler applying the config rules to root spans and
+// spans with a remote parent. Spans with a local parent follow its decision
+func NewRuleSampler(config SamplingConfig) trace.Sampler {
+	samplers := make([]trace.Sampler, len(config.Rules))
+	for i, rule := range config.Rules {
+		samplers[i] = trace.TraceIDRatioBased(rule.Ratio)
+	}
+
+	return ruleSampler{
+		config:   config,
+		samplers: samplers,
+		fallback: trace.TraceIDRatioBased(config.DefaultRatio),
+	}
+}
+
+func (s ruleSampler) ShouldSample(p trace.SamplingParameters) trace.SamplingResult {
+	parent := oteltrace.SpanContextFromContext(p.ParentContext)
+
+	var result trace.SamplingResult
+	switch {
+	case parent.IsValid() && !parent.IsRemote():
+		result = s.fromParent(parent)
+	default:
+		result = s.fromRules(p, parent)
+	}
+
+	if result.Decision == trace.Drop && s.config.RecordErrors {
+		result.Decision = trace.RecordOnly
+	}
+	result.Tracestate = parent.TraceState()
+	return result
+}
+
+func (s ruleSampler) fromParent(parent oteltrace.SpanContext) trace.SamplingResult {
+	if parent.IsSampled() {
+		return trace.SamplingResult{Decision: trace.RecordAndSample}
+	}
+	return trace.SamplingResult{Decision: trace.Drop}
+}
+
+func (s ruleSampler) fromRules(p trace.SamplingParameters, parent oteltrace.SpanContext) trace.SamplingResult {
+	attrs := attribute.NewSet(p.Attributes...)
+	for i, rule := range s.config.Rules {
+		if rule.MinStatus == 0 && rule.matches(attrs) {
+			return s.samplers[i].ShouldSample(p)
+		}
+	}
+
+	if parent.IsValid() {
+		return s.fromParent(parent)
+	}
+	return s.fallback.ShouldSample(p)
+}
+
+func (s ruleSampler) Description() string {
+	return fmt.Sprintf("RuleSampler{rules:%d,default:%g,recordErrors:%t}", len(s.config.Rules), s.config.DefaultRatio, s.config.RecordErrors)
+}
+
+func (r SamplingRule) matches(attrs attribute.Set) bool {
+	if r.Method != "" {
+		method, _ := attrs.Value(semconv.HTTPRequestMethodKey)
+		if !strings.EqualFold(method.AsString(), r.Method) {
+			return false
+		}
+	}
+
+	if r.Route != "" {
+		route, ok := attrs.Value(semconv.HTTPRouteKey)
+		if !ok {
+			route, _ = attrs.Value(semconv.URLPathKey)
+		}
+		if !matchRoute(r.Route, route.AsString()) {
+			return false
+		}
+	}
+
+	if r.MinStatus > 0 {
+		status, _ := attrs.Value(semconv.HTTPResponseStatusCodeKey)
+		if status.AsInt64() < int64(r.MinStatus) {
+			return false
+		}
+	}
+
+	for key, value := range r.Attributes {
+		v, ok := attrs.Value(attribute.Key(key))
+		if !ok || v.Emit() != value {
+			return false
+		}
+	}
+	return true
+}
+
+// matchRoute matches a path against a template where :param matches one
+// segment and a trailing * matches the rest. Templates match themselves
+func matchRoute(template, path string) bool {
+	templateSegments := strings.Split(strings.Trim(template, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, segment := range templateSegments {
+		if segment == "*" {
+			return true
+		}
+		if i >= len(pathSegments) {
+			return false
+		}
+		if !strings.HasPrefix(segment, ":") && segment != pathSegments[i] {
+			return false
+		}
+	}
+	return len(templateSegments) == len(pathSegments)
+}
+
+// keepErrorsProcessor exports the spans recorded but not sampled when they
+// end with error status or match a MinStatus rule
+type keepErrorsProcessor struct {
+	trace.SpanProcessor
+	config SamplingConfig
+}
+
+func (k keepErrorsProcessor) OnEnd(s trace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		k.SpanProcessor.OnEnd(s)
+		return
+	}
+
+	if s.Status().Code == codes.Error || k.matchesStatusRule(s) {
+		k.SpanProcessor.OnEnd(sampledSpan{s})
+	}
+}
+
+// matchesStatusRule applies the ratio of the first MinStatus rule the span matches
+func (k keepErrorsProcessor) matchesStatusRule(s trace.ReadOnlySpan) bool {
+	attrs := attribute.NewSet(s.Attributes()...)
+	for _, rule := range k.config.Rules {
+		if rule.MinStatus > 0 && rule.matches(attrs) {
+			result := trace.TraceIDRatioBased(rule.Ratio).ShouldSample(trace.SamplingParameters{TraceID: s.SpanContext().TraceID()})
+			return result.Decision == trace.RecordAndSample
+		}
+	}
+	return false
+}
+
+func (k keepErrorsProcessor) OnStart(parent context.Context, s trace.ReadWriteSpan) {
+	k.SpanProcessor.OnStart(parent, s)
+}
+
+// sampledSpan marks a recorded span as sampled so the exporters accept it
+type sampledSpan struct {
+	trace.ReadOnlySpan
+}
+
+func (s sampledSpan) SpanContext() oteltrace.SpanContext {
+	return s.ReadOnlySpan.SpanContext().WithTraceFlags(oteltrace.FlagsSampled)
+}
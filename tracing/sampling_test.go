@@ -0,0 +1,141 @@
+package tracing
+
+import (
+	"encoding/binary"
+	"math"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestRuleSampler(t *testing.T) {
+	provider := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(provider) })
+
+	config := SamplingConfig{
+		DefaultRatio: 1,
+		RecordErrors: true,
+		Rules: []SamplingRule{
+			{Route: "/health", Ratio: 0},
+			{Route: "/items/*", Method: "GET", Ratio: 0},
+			{Route: "/items/*", MinStatus: 404, Ratio: 1},
+		},
+	}
+
+	exporter := tracetest.NewInMemoryExporter()
+	processor := keepErrorsProcessor{trace.NewSimpleSpanProcessor(exporter), config}
+	otel.SetTracerProvider(trace.NewTracerProvider(trace.WithSampler(NewRuleSampler(config)), trace.WithSpanProcessor(processor)))
+
+	app := fiber.New()
+	app.Use(Middleware())
+	app.Get("/health", func(c *fiber.Ctx) error { return nil })
+	app.Get("/items/:id", func(c *fiber.Ctx) error {
+		if c.Params("id") == "0" {
+			return fiber.ErrNotFound
+		}
+		return nil
+	})
+	app.Post("/items/:id", func(c *fiber.Ctx) error { return nil })
+	app.Get("/fail", func(c *fiber.Ctx) error { return fiber.ErrInternalServerError })
+
+	for _, r := range [][2]string{
+		{fiber.MethodGet, "/health"},
+		{fiber.MethodGet, "/items/1"},
+		{fiber.MethodGet, "/items/0"},
+		{fiber.MethodPost, "/items/1"},
+		{fiber.MethodGet, "/fail"},
+	} {
+		if _, err := app.Test(httptest.NewRequest(r[0], r[1], nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var names []string
+	for _, s := range exporter.GetSpans() {
+		names = append(names, s.Name)
+	}
+
+	expected := []string{"GET /items/:id", "POST /items/:id", "GET /fail"}
+	if len(names) != len(expected) {
+		t.Fatalf("Exported spans must be %v. Got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Exported spans must be %v. Got %v", expected, names)
+		}
+	}
+}
+
+func TestMatchRoute(t *testing.T) {
+	cases := []struct {
+		template, path string
+		match          bool
+	}{
+		{"/health", "/health", true},
+		{"/health", "/health/live", false},
+		{"/items/:id", "/items/1", true},
+		{"/items/:id", "/items", false},
+		{"/items/:id", "/items/:id", true},
+		{"/api/*", "/api/v1/items", true},
+		{"/api/*", "/other", false},
+	}
+
+	for _, c := range cases {
+		if got := matchRoute(c.template, c.path); got != c.match {
+			t.Errorf("matchRoute(%s, %s) must be %t", c.template, c.path, c.match)
+		}
+	}
+}
+
+func TestLoadSamplingConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sampling.json")
+	data := `{"defaultRatio": 0.5, "recordErrors": true, "rules": [{"route": "/health", "ratio": 0}]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadSamplingConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config.DefaultRatio != 0.5 || !config.RecordErrors || len(config.Rules) != 1 || config.Rules[0].Route != "/health" {
+		t.Errorf("Unexpected config %+v", config)
+	}
+
+	if _, err := LoadSamplingConfig(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Missing file must fail")
+	}
+}
+
+func TestStatusRuleRatio(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	processor := keepErrorsProcessor{trace.NewSimpleSpanProcessor(exporter), SamplingConfig{
+		Rules: []SamplingRule{{MinStatus: 400, Ratio: 0.1}},
+	}}
+
+	// trace IDs spread evenly, away from the ratio boundary
+	stride := uint64(math.MaxUint64 / 1000)
+	for i := range uint64(1000) {
+		var traceID oteltrace.TraceID
+		binary.BigEndian.PutUint64(traceID[8:], i*stride+stride/2)
+
+		processor.OnEnd(tracetest.SpanStub{
+			SpanContext: oteltrace.NewSpanContext(oteltrace.SpanContextConfig{TraceID: traceID, SpanID: oteltrace.SpanID{1}}),
+			Attributes:  []attribute.KeyValue{semconv.HTTPResponseStatusCode(404)},
+		}.Snapshot())
+	}
+
+	if kept := len(exporter.GetSpans()); kept != 100 {
+		t.Errorf("Ratio 0.1 must keep 100 of 1000 error spans. Got %d", kept)
+	}
+}
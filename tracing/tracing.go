@@ -4,51 +4,70 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"time"
+	"slices"
 
 	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/contrib/propagators/aws/xray"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
-	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
-func StartTracing(appName string) (*trace.TracerProvider, error) {
-	headers := map[string]string{
-		"content-type": "application/json",
+// StartTracing configures the global TracerProvider and propagator.
+// By default spans are sent by OTLP/HTTP to TRACING_HOST with always-on sampling
+func StartTracing(appName string, options ...TracingOptions) (*trace.TracerProvider, error) {
+	opts := newTracingOption(options...)
+
+	propagator, err := NewPropagator(opts.propagators...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := NewResource(context.Background(), appName, opts.resource...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating resource: %w", err)
+	}
+
+	if path := os.Getenv("TRACING_SAMPLING_CONFIG"); path != "" && opts.sampler == nil {
+		config, err := LoadSamplingConfig(path)
+		if err != nil {
+			return nil, err
+		}
+		WithSamplingRules(config)(&opts)
 	}
 
-	exporter, err := otlptrace.New(
-		context.Background(),
-		otlptracehttp.NewClient(
-			otlptracehttp.WithEndpoint(os.Getenv("TRACING_HOST")),
-			otlptracehttp.WithHeaders(headers),
-			otlptracehttp.WithInsecure(),
-		),
-	)
+	exporter, err := opts.newExporter(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("error crearing exporter: %w", err)
 	}
 
-	tracerprovider := trace.NewTracerProvider(
-		trace.WithBatcher(
-			exporter,
-			trace.WithMaxExportBatchSize(trace.DefaultMaxExportBatchSize),
-			trace.WithBatchTimeout(trace.DefaultScheduleDelay*time.Millisecond),
-		),
-		trace.WithResource(
-			resource.NewWithAttributes(
-				semconv.SchemaURL,
-				semconv.ServiceNameKey.String(appName),
-			),
-		),
-	)
+	providerOptions := []trace.TracerProviderOption{
+		trace.WithResource(res),
+	}
+
+	switch {
+	case exporter == nil:
+	case opts.sampling != nil && opts.sampling.RecordErrors:
+		// The batcher skips the spans not sampled, so the kept errors are marked as sampled
+		processor := trace.NewBatchSpanProcessor(exporter, opts.batchOptions...)
+		providerOptions = append(providerOptions, trace.WithSpanProcessor(keepErrorsProcessor{processor, *opts.sampling}))
+	default:
+		providerOptions = append(providerOptions, trace.WithBatcher(exporter, opts.batchOptions...))
+	}
+
+	if opts.sampler != nil {
+		providerOptions = append(providerOptions, trace.WithSampler(opts.sampler))
+	}
+
+	// X-Ray only accepts trace IDs starting with the epoch seconds
+	if slices.Contains(opts.propagators, XRayPropagator) {
+		providerOptions = append(providerOptions, trace.WithIDGenerator(xray.NewIDGenerator()))
+	}
+
+	tracerprovider := trace.NewTracerProvider(providerOptions...)
 
 	otel.SetTracerProvider(tracerprovider)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetTextMapPropagator(propagator)
 
 	return tracerprovider, nil
 }
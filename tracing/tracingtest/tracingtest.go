@@ -0,0 +1,184 @@
+package tracingtest
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/javiorfo/go-microservice-lib/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Recorder holds the spans ended and the records logged since Install
+type Recorder struct {
+	spans *tracetest.SpanRecorder
+	logs  *syncBuffer
+}
+
+// Install sets an in-memory TracerProvider, the W3C propagators and a
+// logger writing into the Recorder. The previous ones are restored on t.Cleanup
+func Install(t testing.TB) *Recorder {
+	t.Helper()
+
+	tracerProvider := otel.GetTracerProvider()
+	propagator := otel.GetTextMapPropagator()
+	logger := tracing.Logger()
+
+	recorder := &Recorder{
+		spans: tracetest.NewSpanRecorder(),
+		logs:  &syncBuffer{},
+	}
+
+	provider := trace.NewTracerProvider(trace.WithSpanProcessor(recorder.spans))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	tracing.SetLogger(slog.New(tracing.NewLogHandler(recorder.logs, tracing.WithLevel(slog.LevelDebug))))
+
+	t.Cleanup(func() {
+		// t.Context() is already cancelled when the cleanup runs
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		provider.Shutdown(ctx)
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetTextMapPropagator(propagator)
+		tracing.SetLogger(logger)
+	})
+
+	return recorder
+}
+
+// Spans returns the ended spans in the order they ended
+func (r *Recorder) Spans() []trace.ReadOnlySpan {
+	return r.spans.Ended()
+}
+
+// Span returns the first ended span named name, failing the test if there is none
+func (r *Recorder) Span(t testing.TB, name string) Span {
+	t.Helper()
+
+	for _, span := range r.Spans() {
+		if span.Name() == name {
+			return Span{t, span, r}
+		}
+	}
+
+	t.Fatalf("span %q not found in %v", name, r.names())
+	return Span{}
+}
+
+// Logs returns the records logged through tracing.Logger
+func (r *Recorder) Logs(t testing.TB) []map[string]any {
+	t.Helper()
+
+	var records []map[string]any
+	scanner := bufio.NewScanner(bytes.NewReader(r.logs.Bytes()))
+	for scanner.Scan() {
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid log record %s: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func (r *Recorder) names() []string {
+	var names []string
+	for _, span := range r.Spans() {
+		names = append(names, span.Name())
+	}
+	return names
+}
+
+// Span asserts a recorded span. Every method fails the test when the assertion does not hold
+type Span struct {
+	t        testing.TB
+	span     trace.ReadOnlySpan
+	recorder *Recorder
+}
+
+// ReadOnlySpan returns the recorded span for custom assertions
+func (s Span) ReadOnlySpan() trace.ReadOnlySpan {
+	return s.span
+}
+
+func (s Span) HasStatus(code codes.Code) Span {
+	s.t.Helper()
+	if s.span.Status().Code != code {
+		s.t.Errorf("span %q: status must be %s. Got %s", s.span.Name(), code, s.span.Status().Code)
+	}
+	return s
+}
+
+func (s Span) HasEvent(name string) Span {
+	s.t.Helper()
+	for _, event := range s.span.Events() {
+		if event.Name == name {
+			return s
+		}
+	}
+	s.t.Errorf("span %q: event %q not found in %v", s.span.Name(), name, s.span.Events())
+	return s
+}
+
+// HasAttribute compares value with the attribute value formatted as string
+func (s Span) HasAttribute(key attribute.Key, value any) Span {
+	s.t.Helper()
+	for _, attr := range s.span.Attributes() {
+		if attr.Key == key {
+			if attr.Value.Emit() != fmt.Sprint(value) {
+				s.t.Errorf("span %q: attribute %s must be %v. Got %s", s.span.Name(), key, value, attr.Value.Emit())
+			}
+			return s
+		}
+	}
+	s.t.Errorf("span %q: attribute %s not found", s.span.Name(), key)
+	return s
+}
+
+// HasParent checks the span is a child of the recorded span named parent
+func (s Span) HasParent(parent string) Span {
+	s.t.Helper()
+	p := s.recorder.Span(s.t, parent).span
+	if s.span.Parent().SpanID() != p.SpanContext().SpanID() {
+		s.t.Errorf("span %q: parent must be %q", s.span.Name(), parent)
+	}
+	return s
+}
+
+// IsRoot checks the span has no parent
+func (s Span) IsRoot() Span {
+	s.t.Helper()
+	if s.span.Parent().IsValid() {
+		s.t.Errorf("span %q: must be a root span", s.span.Name())
+	}
+	return s
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return bytes.Clone(b.buf.Bytes())
+}
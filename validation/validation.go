@@ -2,13 +2,20 @@ package validation
 
 import (
 	"fmt"
+	"maps"
 	"slices"
 	"strings"
+	"sync"
 
+	"github.com/go-playground/locales"
 	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/es"
+	"github.com/go-playground/locales/pt"
 	ut "github.com/go-playground/universal-translator"
 	"github.com/go-playground/validator/v10"
 	en_translations "github.com/go-playground/validator/v10/translations/en"
+	es_translations "github.com/go-playground/validator/v10/translations/es"
+	pt_translations "github.com/go-playground/validator/v10/translations/pt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/javiorfo/go-microservice-lib/response"
 	"go.opentelemetry.io/otel/codes"
@@ -16,11 +23,80 @@ import (
 )
 
 type customValidator struct {
-	tag       Tag
-	jsonField JsonField
-	validate  validator.Func
-	code      string
-	message   string
+	tag          Tag
+	jsonField    JsonField
+	validate     validator.Func
+	code         string
+	message      string
+	translations map[string]response.Message
+}
+
+// WithTranslation sets the message of the validator for lang
+func (cv customValidator) WithTranslation(lang string, message response.Message) customValidator {
+	cv.translations = maps.Clone(cv.translations)
+	if cv.translations == nil {
+		cv.translations = make(map[string]response.Message)
+	}
+	cv.translations[strings.ToLower(lang)] = message
+	return cv
+}
+
+// messageFor returns the message of lang, falling back to its base
+// language (pt for pt-BR) and then to English
+func (cv customValidator) messageFor(lang string) response.Message {
+	if msg, ok := cv.translations[lang]; ok {
+		return msg
+	}
+	if msg, ok := cv.translations[baseLanguage(lang)]; ok {
+		return msg
+	}
+	return cv.message
+}
+
+// Locale holds the validator messages of a language
+type Locale struct {
+	Translator   locales.Translator
+	Translations func(*validator.Validate, ut.Translator) error
+	InvalidBody  response.Message
+}
+
+var (
+	localesMu     sync.RWMutex
+	localesByLang = map[string]Locale{
+		"en": {en.New(), en_translations.RegisterDefaultTranslations, "Invalid Request Body"},
+		"es": {es.New(), es_translations.RegisterDefaultTranslations, "Cuerpo de la solicitud inválido"},
+		"pt": {pt.New(), pt_translations.RegisterDefaultTranslations, "Corpo da requisição inválido"},
+	}
+)
+
+// RegisterLocale adds or replaces the validator messages of lang and
+// makes it negotiable by Accept-Language (see response.Language)
+func RegisterLocale(lang string, locale Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+
+	localesByLang[strings.ToLower(lang)] = locale
+	response.RegisterLanguage(lang)
+}
+
+// localeFor returns the locale of lang, falling back to its base language and then to English
+func localeFor(lang string) Locale {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	if locale, ok := localesByLang[lang]; ok {
+		return locale
+	}
+	if locale, ok := localesByLang[baseLanguage(lang)]; ok {
+		return locale
+	}
+	return localesByLang["en"]
+}
+
+// baseLanguage returns the language without region (e.g. pt for pt-BR)
+func baseLanguage(lang string) string {
+	base, _, _ := strings.Cut(lang, "-")
+	return base
 }
 
 type Tag = string
@@ -29,33 +105,30 @@ type JsonField = string
 type FuncCode = func(response.ErrorCode) customValidator
 type FuncCodeAndMessage = func(response.ErrorCode, response.Message) customValidator
 
+// ValidateRequest parses and validates the body. The messages are in
+// the language negotiated from Accept-Language, falling back to English
 func ValidateRequest[T any](c *fiber.Ctx, span trace.Span, code response.ErrorCode, customValidators ...customValidator) (*T, *response.ResponseError) {
 	entity := new(T)
+	lang := response.Language(c)
+	locale := localeFor(lang)
 
 	if err := c.BodyParser(entity); err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		return nil, response.NewResponseError(span, response.Error{
 			HttpStatus: fiber.StatusBadRequest,
 			Code:       code,
-			Message:    response.Message("Invalid Request Body"),
+			Message:    locale.InvalidBody,
 		})
 	}
 
 	validate := validator.New()
-	enLocale := en.New()
-	uni := ut.New(enLocale, enLocale)
-	translator, _ := uni.GetTranslator("en")
+	uni := ut.New(locale.Translator, locale.Translator)
+	translator, _ := uni.GetTranslator(locale.Translator.Locale())
 
-	en_translations.RegisterDefaultTranslations(validate, translator)
+	locale.Translations(validate, translator)
 
 	for _, cv := range customValidators {
 		validate.RegisterValidation(cv.tag, cv.validate)
-		validate.RegisterTranslation(cv.tag, translator, func(ut ut.Translator) error {
-			return ut.Add(cv.tag, "{0} "+cv.message, true)
-		}, func(ut ut.Translator, fe validator.FieldError) string {
-			t, _ := ut.T(cv.tag, cv.jsonField)
-			return t
-		})
 	}
 
 	if err := validate.Struct(entity); err != nil {
@@ -68,14 +141,12 @@ func ValidateRequest[T any](c *fiber.Ctx, span trace.Span, code response.ErrorCo
 
 	errorsLoop:
 		for _, e := range validationErrors {
-			msg := e.Translate(translator)
-
 			for _, cv := range customValidators {
-				if strings.Contains(msg, cv.message) {
+				if e.Tag() == cv.tag {
 					restResponseError.Add(span, response.Error{
 						HttpStatus: fiber.StatusBadRequest,
 						Code:       response.ErrorCode(cv.code),
-						Message:    response.Message(cv.message),
+						Message:    cv.messageFor(lang),
 					})
 					continue errorsLoop
 				}
@@ -84,7 +155,7 @@ func ValidateRequest[T any](c *fiber.Ctx, span trace.Span, code response.ErrorCo
 			restResponseError.Add(span, response.Error{
 				HttpStatus: fiber.StatusBadRequest,
 				Code:       response.ErrorCode(code),
-				Message:    response.Message(msg),
+				Message:    response.Message(e.Translate(translator)),
 			})
 		}
 		return nil, restResponseError
@@ -106,9 +177,12 @@ func NewCustomValidator(tag Tag, jsonField JsonField, validate validator.Func) F
 
 func NewEnumValidator(tag Tag, jsonField JsonField, enums ...string) FuncCode {
 	return func(errorCode response.ErrorCode) customValidator {
+		values := strings.Join(enums, ", ")
 		return NewCustomValidator(tag, jsonField, func(fl validator.FieldLevel) bool {
 			return slices.Contains(enums, fl.Field().String())
-		})(errorCode, fmt.Sprintf("Field %s must be one of %s", jsonField, strings.Join(enums, ", ")))
+		})(errorCode, fmt.Sprintf("Field %s must be one of %s", jsonField, values)).
+			WithTranslation("es", fmt.Sprintf("El campo %s debe ser uno de %s", jsonField, values)).
+			WithTranslation("pt", fmt.Sprintf("O campo %s deve ser um de %s", jsonField, values))
 	}
 }
 
@@ -116,6 +190,8 @@ func NewNotBlankValidator(jsonField JsonField) FuncCode {
 	return func(errorCode response.ErrorCode) customValidator {
 		return NewCustomValidator("notblank", jsonField, func(fl validator.FieldLevel) bool {
 			return strings.TrimSpace(fl.Field().String()) != ""
-		})(errorCode, fmt.Sprintf("Field %s must be not be empty", jsonField))
+		})(errorCode, fmt.Sprintf("Field %s must be not be empty", jsonField)).
+			WithTranslation("es", fmt.Sprintf("El campo %s no debe estar vacío", jsonField)).
+			WithTranslation("pt", fmt.Sprintf("O campo %s não deve estar vazio", jsonField))
 	}
 }
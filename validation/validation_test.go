@@ -0,0 +1,87 @@
+package validation
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/locales/pt_BR"
+	pt_translations "github.com/go-playground/validator/v10/translations/pt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/javiorfo/go-microservice-lib/response"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type item struct {
+	Name   string `json:"name" validate:"required"`
+	Status string `json:"status" validate:"status"`
+}
+
+func TestValidateRequestLocalized(t *testing.T) {
+	statusValidator := NewEnumValidator("status", "status", "ACTIVE", "INACTIVE")("INVALID_STATUS")
+
+	var got *response.ResponseError
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		_, got = ValidateRequest[item](c, noop.Span{}, "INVALID_ITEM", statusValidator)
+		return nil
+	})
+
+	validate := func(lang, body string) *response.ResponseError {
+		req := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Set(fiber.HeaderAcceptLanguage, lang)
+		if _, err := app.Test(req); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	re := validate("es-AR", `{"status":"DELETED"}`)
+	if re == nil || len(re.Errors) != 2 {
+		t.Fatalf("Two errors expected. Got %+v", re)
+	}
+	if msg := re.Errors[0].Message; msg != "Name es un campo requerido" {
+		t.Errorf("Validator message must be in Spanish. Got %s", msg)
+	}
+	if e := re.Errors[1]; e.Code != "INVALID_STATUS" || e.Message != "El campo status debe ser uno de ACTIVE, INACTIVE" {
+		t.Errorf("Custom validator message must be in Spanish. Got %+v", e)
+	}
+
+	if re := validate("pt", `{`); re.Get().Message != "Corpo da requisição inválido" {
+		t.Errorf("Invalid body message must be in Portuguese. Got %+v", re)
+	}
+
+	if re := validate("fr", `{"status":"ACTIVE"}`); re.Get().Message != "Name is a required field" {
+		t.Errorf("Unknown language must fall back to English. Got %+v", re)
+	}
+}
+
+func TestValidateRequestRegionalLocale(t *testing.T) {
+	RegisterLocale("pt-BR", Locale{pt_BR.New(), pt_translations.RegisterDefaultTranslations, "Corpo da requisição inválido"})
+	t.Cleanup(func() {
+		localesMu.Lock()
+		defer localesMu.Unlock()
+		delete(localesByLang, "pt-br")
+	})
+
+	statusValidator := NewEnumValidator("status", "status", "ACTIVE", "INACTIVE")("INVALID_STATUS")
+
+	var got *response.ResponseError
+	app := fiber.New()
+	app.Post("/", func(c *fiber.Ctx) error {
+		_, got = ValidateRequest[item](c, noop.Span{}, "INVALID_ITEM", statusValidator)
+		return nil
+	})
+
+	req := httptest.NewRequest(fiber.MethodPost, "/", strings.NewReader(`{"name":"box","status":"DELETED"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderAcceptLanguage, "pt-BR")
+	if _, err := app.Test(req); err != nil {
+		t.Fatal(err)
+	}
+
+	if got == nil || got.Get().Message != "O campo status deve ser um de ACTIVE, INACTIVE" {
+		t.Errorf("Custom validator message must fall back to Portuguese. Got %+v", got)
+	}
+}